@@ -0,0 +1,193 @@
+package breakout
+
+import (
+	"hackweek/engine"
+	"math"
+)
+
+const (
+	MaxBallSpeed        = 2000
+	MaxBalls            = 12
+	MultiballSpreadDegs = 20 // Angle each extra ball from a split heads off at
+	LaunchMaxAngle      = 45 // Degrees off straight up when the pad is moving at full speed
+	LaunchIdleAngle     = 15 // Degrees off straight up when the pad is standing still
+)
+
+// Ball is a breakout ball. Each one collides on its own and can be stuck to a sticky pad.
+type Ball struct {
+	engine.Ball
+	isStuck       bool
+	isServe       bool // Waiting on the pad to be launched, with no time limit
+	stuckOffsetX  float32
+	stuckVelocity engine.Vector2
+	m_TimerStuck  float32
+}
+
+var balls []*Ball
+
+var padSpeedX float32 // How fast the pad moved on the last tick, for launching
+
+// ResetBalls leaves a single ball sitting on the middle of the pad, waiting to be launched.
+func ResetBalls() {
+	ball := &Ball{}
+	ball.Size = engine.Vector2{10, 10}
+	ball.isStuck = true
+	ball.isServe = true
+	ball.Teleport(engine.Vector2{player1.CenterPosition.X, player1.Min().Y - (ball.Size.Y / 2)})
+	balls = []*Ball{ball}
+}
+
+func UpdateBalls(deltaTime float32) {
+	ballDeltaTime := deltaTime
+	if IsPowerUpActive(SlowerBall) {
+		ballDeltaTime *= SlowBallTimeScale
+	}
+	for _, ball := range balls {
+		if ball.isStuck {
+			ball.UpdateStuck(deltaTime)
+			continue
+		}
+		ball.MoveSwept(ballDeltaTime, ball.Collide)
+	}
+
+	// Drop the balls that fell off the bottom
+	remaining := balls[:0]
+	for _, ball := range balls {
+		if ball.CenterPosition.Y <= engine.ScreenHeight {
+			remaining = append(remaining, ball)
+		}
+	}
+	balls = remaining
+}
+
+// SplitBalls sends two more balls off at an angle from every moving ball, up to MaxBalls.
+// Balls held by a sticky pad stay as they are.
+func SplitBalls() {
+	hasMovingBall := false
+	for _, ball := range balls {
+		hasMovingBall = hasMovingBall || !ball.isStuck
+	}
+	if !hasMovingBall {
+		for _, ball := range balls {
+			ball.Launch()
+		}
+	}
+
+	for _, ball := range balls {
+		if ball.isStuck {
+			continue
+		}
+		for _, degrees := range []float32{-MultiballSpreadDegs, MultiballSpreadDegs} {
+			if len(balls) >= MaxBalls {
+				return
+			}
+			split := &Ball{Ball: ball.Ball}
+			split.Velocity = rotate(ball.Velocity, degrees*engine.Deg2rad)
+			balls = append(balls, split)
+		}
+	}
+}
+
+func rotate(v engine.Vector2, radians float32) engine.Vector2 {
+	sin, cos := math.Sincos(float64(radians))
+	return engine.Vector2{
+		v.X*float32(cos) - v.Y*float32(sin),
+		v.X*float32(sin) + v.Y*float32(cos),
+	}
+}
+
+// Collide finds the first wall, bricks or pad the ball would hit along
+// movement and responds to it: walls bounce the ball, bricks take a hit and
+// bounce it, the pad aims it. Every brick reached at that same moment takes a hit.
+func (ball *Ball) Collide(movement engine.Vector2) (engine.Hit, bool) {
+	nearest := engine.Hit{}
+	hasHit := false
+	for _, wall := range walls {
+		hit, ok := engine.SweptAABB(ball.Rectangle, movement, wall)
+		if ok && (!hasHit || hit.Time < nearest.Time) {
+			nearest = hit
+			hasHit = true
+		}
+	}
+	brickHits := FirstBrickHits(ball.Rectangle, movement)
+	if len(brickHits) > 0 && (!hasHit || brickHits[0].Time < nearest.Time) {
+		nearest = brickHits[0].Hit
+		hasHit = true
+	} else {
+		brickHits = nil
+	}
+	padHit, hasPadHit := engine.SweptAABB(ball.Rectangle, movement, player1.Rectangle)
+	if hasPadHit && (!hasHit || padHit.Time < nearest.Time) {
+		hitX := ball.CenterPosition.X + movement.X*padHit.Time
+		if IsPowerUpActive(StickyPad) {
+			ball.Stick(hitX)
+		} else {
+			ball.BounceOffPad(hitX)
+		}
+		return padHit, true
+	}
+	for _, brickHit := range brickHits {
+		HitBrick(brickHit.I, brickHit.J)
+	}
+	if hasHit {
+		ball.Velocity = engine.Reflect(ball.Velocity, nearest.Normal)
+	}
+	return nearest, hasHit
+}
+
+// BounceOffPad aims the ball by where it hit along the pad and speeds it up.
+func (ball *Ball) BounceOffPad(hitX float32) {
+	combo = 0
+	previousVelocity := ball.Velocity
+	distanceX := hitX - player1.CenterPosition.X
+	percentage := distanceX / (player1.Size.X / 2)
+	ball.Velocity.X = InitialBallVelocity.X * percentage
+	ball.Velocity.Y = -engine.Max(ball.Velocity.Y, -ball.Velocity.Y)
+	newVelocity := engine.Vector2Scale(engine.Vector2Normalize(ball.Velocity), engine.Min(engine.Vector2Length(previousVelocity)*1.1, MaxBallSpeed))
+	ball.Velocity = newVelocity
+}
+
+// Stick holds the ball on the pad where it landed until it is launched.
+func (ball *Ball) Stick(hitX float32) {
+	ball.isStuck = true
+	ball.stuckOffsetX = hitX - player1.CenterPosition.X
+	ball.stuckVelocity = ball.Velocity
+	ball.Velocity = engine.Vector2{}
+	ball.m_TimerStuck = StickyPadMaxHoldSeconds
+}
+
+// UpdateStuck carries the ball along with the pad and launches it on Shoot.
+// Only balls caught by a sticky pad launch by themselves when held too long.
+func (ball *Ball) UpdateStuck(deltaTime float32) {
+	ball.StartTick()
+	ball.CenterPosition.X = player1.CenterPosition.X + ball.stuckOffsetX
+	ball.CenterPosition.Y = player1.Min().Y - (ball.Size.Y / 2)
+	if player1.Input.IsPressed(engine.Shoot) || (!ball.isServe && engine.HasHitTime(&ball.m_TimerStuck, deltaTime)) {
+		ball.Launch()
+	}
+}
+
+func (ball *Ball) Launch() {
+	if !ball.isStuck {
+		return
+	}
+	ball.isStuck = false
+	if ball.isServe {
+		ball.isServe = false
+		ball.Serve()
+		return
+	}
+	ball.Velocity = ball.stuckVelocity
+	ball.BounceOffPad(ball.CenterPosition.X)
+}
+
+// Serve sends the ball up off the pad at the level's speed, angled the way the pad is moving.
+func (ball *Ball) Serve() {
+	angle := float32(LaunchIdleAngle)
+	if padSpeedX != 0 {
+		angle = engine.Max(-1, engine.Min(padSpeedX/player1.Velocity.X, 1)) * LaunchMaxAngle
+	}
+	speed := engine.Vector2Length(InitialBallVelocity)
+	ball.Velocity = rotate(engine.Vector2{0, -speed}, angle*engine.Deg2rad)
+	combo = 0
+}
@@ -1,11 +1,17 @@
-package main
-
-import raylib "github.com/gen2brain/raylib-go/raylib"
-import "math/rand"
+// Package breakout is the game of breakout and its level editor.
+// The program that opens a window for it is cmd/breakout.
+package breakout
+
+import (
+	"flag"
+	"fmt"
+	"hackweek/engine"
+	"math/rand"
+	"os"
+	"strconv"
+)
 
 const (
-	BoardWidthInBricks  = 12
-	BoardHeightInBricks = 13
 	BrickWidthInPixels  = 64
 	BrickHeightInPixels = 24
 )
@@ -16,295 +22,248 @@ const (
 )
 
 const (
-	None = iota - 1
-	Left
-	Top
-	Right
-	Bottom
+	StartingLives            = 3
+	ComboBricksPerMultiplier = 3 // Bricks broken without touching the pad for each step up in multiplier
+	MaxComboMultiplier       = 5
 )
 
-type Brick struct {
-	typeOf  int
-	isAlive bool
-}
+var player1 engine.Pad
+var bricks [][]*Brick // bricks[i][j] is column i, row j
+var walls []engine.Rectangle
+var rng *rand.Rand
+
+var levels []*Level
+var levelIndex int
+var background engine.Color
+
+var numLives int
+var combo int // Bricks broken since a ball last touched the pad
+var IsGameOver bool
+
+var InitialBallVelocity engine.Vector2
+
+// Main reads the command line and plays the game on platform. cmd/breakout calls it with the raylib backend.
+func Main(platform engine.Platform) {
+	seed := engine.SeedFlag()
+	levelFile := flag.String("level", "", "play only this level file instead of the bundled levels")
+	editFile := flag.String("edit", "", "open this level file in the editor, creating it on save if it doesn't exist")
+	flag.Parse()
+	rng = engine.NewRand(*seed)
+
+	var err error
+	if *editFile != "" {
+		err = OpenEditor(*editFile)
+	} else if *levelFile != "" {
+		var level *Level
+		level, err = LoadLevel(*levelFile)
+		levels = []*Level{level}
+	} else {
+		levels, err = LoadBundledLevels()
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
 
-type Rectangle struct {
-	centerPosition raylib.Vector2
-	size           raylib.Vector2
+	player1.Input = platform.NewKeyboardInput(map[engine.Action]engine.Key{
+		engine.MoveLeft:  engine.KeyA,
+		engine.MoveRight: engine.KeyD,
+		engine.Shoot:     engine.KeySpace,
+		engine.Confirm:   engine.KeyEnter,
+		engine.Pause:     engine.KeyP,
+	})
+	editorInput = platform.NewMouseInput(map[engine.Action]engine.Key{
+		engine.MoveRight:  engine.KeyRight,
+		engine.MoveLeft:   engine.KeyLeft,
+		engine.MoveDown:   engine.KeyDown,
+		engine.MoveUp:     engine.KeyUp,
+		engine.SwitchMode: engine.KeyTab,
+		engine.Save:       engine.KeyS,
+		engine.Load:       engine.KeyL,
+	}, map[engine.Action]engine.MouseButton{
+		engine.PointerPrimary:   engine.MouseLeftButton,
+		engine.PointerSecondary: engine.MouseRightButton,
+	})
+
+	platform.Run(engine.Game{
+		Title:  "GO Breakout",
+		Setup:  SetupGame,
+		Update: scenes.Update,
+		Draw:   scenes.Draw,
+	})
 }
 
-type Ball struct {
-	Rectangle
-	velocity raylib.Vector2
-}
+func SetupGame() {
+	screenSizeX := engine.ScreenWidth
+	screenSizeY := engine.ScreenHeight
+
+	{ // Set up walls just outside the top, left and right of the screen
+		wallThickness := float32(100)
+		walls = []engine.Rectangle{
+			{CenterPosition: engine.Vector2{float32(screenSizeX / 2), -wallThickness / 2}, Size: engine.Vector2{float32(screenSizeX) + 2*wallThickness, wallThickness}},
+			{CenterPosition: engine.Vector2{-wallThickness / 2, float32(screenSizeY / 2)}, Size: engine.Vector2{wallThickness, float32(screenSizeY) + 2*wallThickness}},
+			{CenterPosition: engine.Vector2{float32(screenSizeX) + wallThickness/2, float32(screenSizeY / 2)}, Size: engine.Vector2{wallThickness, float32(screenSizeY) + 2*wallThickness}},
+		}
+	}
+	{ // Set up player
+		player1.Size = engine.Vector2{PadWidth, 5}
+		player1.Velocity = engine.Vector2{100, 100}
+	}
 
-type InputScheme struct {
-	leftButton  int32
-	rightButton int32
+	SetupScenes()
 }
 
-type Pad struct {
-	Rectangle
-	InputScheme
-	score    int
-	velocity raylib.Vector2
+// StartGame starts over from the first level with a full set of lives and no score.
+func StartGame() {
+	numLives = StartingLives
+	player1.Score = 0
+	IsGameOver = false
+	levelIndex = 0
+	StartLevel(levels[levelIndex])
 }
 
-var ball Ball
-var player1 Pad
-var bricks [BoardWidthInBricks][BoardHeightInBricks]*Brick
-
-var InitialBallPosition raylib.Vector2
-var InitialBallVelocity raylib.Vector2
-
-func main() {
-	raylib.InitWindow(800, 450, "GO Breakout")
-	defer raylib.CloseWindow()
-	raylib.SetTargetFPS(60)
-
-	SetupGame()
-
-	for !raylib.WindowShouldClose() {
-		dt := raylib.GetFrameTime()
-		Update(dt)
-		Draw()
-	}
-}
-
-func SetupGame() {
-	screenSizeX := raylib.GetScreenWidth()
-	screenSizeY := raylib.GetScreenHeight()
-
-	{ // Setup bricks
-		for i := 0; i < BoardWidthInBricks; i++ {
-			for j := 0; j < BoardHeightInBricks; j++ {
-				bricks[i][j] = new(Brick)
-				bricks[i][j].typeOf = rand.Intn(4)
-				bricks[i][j].isAlive = true
+// StartLevel builds the bricks for level and puts the player back at the start with a ball to serve.
+func StartLevel(level *Level) {
+	bricks = make([][]*Brick, level.Width)
+	for i := range bricks {
+		bricks[i] = make([]*Brick, level.Height)
+		for j := range bricks[i] {
+			switch typeOf := level.Cells[i][j]; typeOf {
+			case NoBrick:
+				bricks[i][j] = &Brick{}
+			case RandomBrick:
+				bricks[i][j] = NewBrick(rng.Intn(NumRandomBrickTypes))
+			default:
+				bricks[i][j] = NewBrick(typeOf)
 			}
 		}
 	}
-	{ // Set up ball
-		InitialBallPosition = raylib.Vector2{float32(screenSizeX / 2), float32(screenSizeY - 20)}
-		InitialBallVelocity = raylib.Vector2{50, -25}
-		ball.velocity = InitialBallVelocity
-		ball.centerPosition = InitialBallPosition
-		ball.size = raylib.Vector2{10, 10}
-	}
-	{ // Set up player
-		player1.size = raylib.Vector2{50, 5}
-		player1.velocity = raylib.Vector2{100, 100}
-		player1.centerPosition = raylib.Vector2{float32(screenSizeX / 2), float32(screenSizeY - 10)}
-		player1.InputScheme = InputScheme{
-			raylib.KeyA,
-			raylib.KeyD,
-		}
-	}
+	background = level.Background
+	ResetPowerUps()
+	combo = 0
+
+	// Keep the original launch angle, at the level's speed
+	InitialBallVelocity = engine.Vector2Scale(engine.Vector2Normalize(engine.Vector2{50, -25}), level.BallSpeed)
+	player1.Teleport(engine.Vector2{float32(engine.ScreenWidth / 2), float32(engine.ScreenHeight - 10)})
+	ResetBalls()
 }
 
 func Update(deltaTime float32) {
-	height := raylib.GetScreenHeight()
-	width := raylib.GetScreenWidth()
-	collisionFace := None
+	width := engine.ScreenWidth
+
+	player1.Input.Update()
+	if player1.Input.IsPressed(engine.Pause) {
+		scenes.Push(pausedScene)
+		return
+	}
 
 	{ // Update Player
-		if raylib.IsKeyDown(player1.rightButton) {
-			// Update position
-			player1.centerPosition.X += (deltaTime * player1.velocity.X)
-			// Clamp on right edge
-			if player1.centerPosition.X+(player1.size.X/2) > float32(width) {
-				player1.centerPosition.X = float32(width) - (player1.size.X / 2)
-			}
+		player1.StartTick()
+		previousX := player1.CenterPosition.X
+		if player1.Input.IsDown(engine.MoveRight) {
+			player1.MoveX(deltaTime*player1.Velocity.X, width)
 		}
-		if raylib.IsKeyDown(player1.leftButton) {
-			// Update position
-			player1.centerPosition.X -= (deltaTime * player1.velocity.X)
-			// Clamp on left edge
-			if player1.centerPosition.X-(player1.size.X/2) < 0 {
-				player1.centerPosition.X = (player1.size.X / 2)
-			}
+		if player1.Input.IsDown(engine.MoveLeft) {
+			player1.MoveX(-deltaTime*player1.Velocity.X, width)
 		}
+		padSpeedX = (player1.CenterPosition.X - previousX) / deltaTime
 	}
-	{ // Update ball
-		ball.centerPosition.X += deltaTime * ball.velocity.X
-		ball.centerPosition.Y += deltaTime * ball.velocity.Y
+	{ // Update power-ups
+		UpdatePowerUps(deltaTime)
 	}
-	// Collisions
-	{ // ball boundary collisions
-		isBallOnBottomScreenEdge := ball.centerPosition.Y > float32(height)
-		isBallOnTopScreenEdge := ball.centerPosition.Y < float32(0)
-		isBallOnLeftRightScreenEdge := ball.centerPosition.X > float32(width) || ball.centerPosition.X < float32(0)
-		if isBallOnBottomScreenEdge {
-			ball.centerPosition = InitialBallPosition
-			ball.velocity = InitialBallVelocity
-		}
-		if isBallOnTopScreenEdge {
-			ball.velocity.Y *= -1
-		}
-		if isBallOnLeftRightScreenEdge {
-			ball.velocity.X *= -1
-		}
+	{ // Update balls, colliding with bricks and the pad on the way
+		UpdateBalls(deltaTime)
 	}
-	{ // ball brick collisions
-		hasHit := false
-		for i := 0; i < BoardWidthInBricks; i++ {
-			for j := 0; j < BoardHeightInBricks; j++ {
-				brick := bricks[i][j]
-				if !brick.isAlive {
-					continue
-				}
-
-				// Coords
-				brickX := float32(BrickOffsetX + (i * BrickWidthInPixels))
-				brickY := float32(BrickOffsetY + (j * BrickHeightInPixels))
-
-				// Ball position
-				ballX := ball.centerPosition.X - (ball.size.X / 2)
-				ballY := ball.centerPosition.Y - (ball.size.Y / 2)
-
-				// Center Brick
-				brickCenterX := brickX + (BrickWidthInPixels / 2)
-				brickCenterY := brickY + (BrickHeightInPixels / 2)
-
-				hasCollisionX := ballX+ball.size.X >= brickX && brickX+BrickWidthInPixels >= ballX
-				hasCollisionY := ballY+ball.size.Y >= brickY && brickY+BrickHeightInPixels >= ballY
-
-				if hasCollisionX && hasCollisionY {
-					brick.isAlive = false
-					hasHit = true
-
-					// Determine which face of the brick was hit
-					ymin := Max(brickY, ballY)
-					ymax := Min(brickY+BrickHeightInPixels, ballY+ball.size.Y)
-					ysize := ymax - ymin
-					xmin := Max(brickX, ballX)
-					xmax := Min(brickX+BrickWidthInPixels, ballX+ball.size.X)
-					xsize := xmax - xmin
-					if xsize > ysize && ball.centerPosition.Y > brickCenterY {
-						collisionFace = Bottom
-					} else if xsize > ysize && ball.centerPosition.Y <= brickCenterY {
-						collisionFace = Top
-					} else if xsize <= ysize && ball.centerPosition.X > brickCenterX {
-						collisionFace = Right
-					} else if xsize <= ysize && ball.centerPosition.X <= brickCenterX {
-						collisionFace = Left
-					} else {
-						// Could assert or panic here
-					}
-
-					break
-				}
-			}
-			if hasHit {
-				break
+	{ // Last ball fell off the bottom
+		if len(balls) == 0 {
+			LoseLife()
+			if IsGameOver {
+				scenes.Push(gameOverScene)
+				return
 			}
 		}
 	}
-	{ // Update ball after collision
-		if collisionFace != None {
-			hasPositiveX := ball.velocity.X > 0
-			hasPositiveY := ball.velocity.Y > 0
-			if (collisionFace == Top && hasPositiveX && hasPositiveY) ||
-				(collisionFace == Top && !hasPositiveX && hasPositiveY) ||
-				(collisionFace == Bottom && hasPositiveX && !hasPositiveY) ||
-				(collisionFace == Bottom && !hasPositiveX && !hasPositiveY) {
-				ball.velocity.Y *= -1
-			}
-			if (collisionFace == Left && hasPositiveX && hasPositiveY) ||
-				(collisionFace == Left && hasPositiveX && !hasPositiveY) ||
-				(collisionFace == Right && !hasPositiveX && hasPositiveY) ||
-				(collisionFace == Right && !hasPositiveX && !hasPositiveY) {
-				ball.velocity.X *= -1
-			}
-		}
-	}
-	{ // Update ball after pad collision
-		if DetectBallTouchesPad(ball, &player1) {
-			previousVelocity := ball.velocity
-			distanceX := ball.centerPosition.X - player1.centerPosition.X
-			percentage := distanceX / (player1.size.X / 2)
-			ball.velocity.X = InitialBallVelocity.X * percentage
-			ball.velocity.Y *= -1
-			newVelocity := raylib.Vector2Scale(raylib.Vector2Normalize(ball.velocity), (raylib.Vector2Length(previousVelocity) * 1.1))
-			ball.velocity = newVelocity
-		}
-	}
 	{ // Detect all bricks popped
 		hasAtLeastOneBrick := false
-		for i := 0; i < BoardWidthInBricks; i++ {
-			for j := 0; j < BoardHeightInBricks; j++ {
+		for i := range bricks {
+			for j := range bricks[i] {
 				brick := bricks[i][j]
-				if brick.isAlive {
+				if brick.isAlive && !brick.IsIndestructible() {
 					hasAtLeastOneBrick = true
-					break	// NOTE: This needs to break all the way out to be a proper comparison of identical code execution
+					break // NOTE: This needs to break all the way out to be a proper comparison of identical code execution
 				}
 			}
 		}
 		if !hasAtLeastOneBrick {
-			SetupGame()
+			levelIndex = (levelIndex + 1) % len(levels)
+			StartLevel(levels[levelIndex])
 		}
 	}
 }
 
-func Draw() {
-	raylib.BeginDrawing()
-	defer raylib.EndDrawing()
-	raylib.ClearBackground(raylib.Black)
+func Draw(renderer engine.Renderer, alpha float32) {
+	renderer.Clear(background)
 
 	{ // Draw alive bricks
-		for i := 0; i < BoardWidthInBricks; i++ {
-			for j := 0; j < BoardHeightInBricks; j++ {
+		for i := range bricks {
+			for j := range bricks[i] {
 				if !bricks[i][j].isAlive {
 					continue
 				}
 
-				raylib.DrawRectangle(int32(BrickOffsetX+(i*BrickWidthInPixels)), int32(BrickOffsetY+(j*BrickHeightInPixels)), BrickWidthInPixels, BrickHeightInPixels, TypeToColor(bricks[i][j].typeOf))
+				engine.DrawRectangle(renderer, BrickRectangle(i, j), bricks[i][j].Color())
 			}
 		}
 	}
+	{ // Draw power-ups
+		DrawPowerUps(renderer, alpha)
+	}
 	{ // Draw Players
-		raylib.DrawRectangle(int32(player1.centerPosition.X-(player1.size.X/2)), int32(player1.centerPosition.Y-(player1.size.Y/2)), int32(player1.size.X), int32(player1.size.Y), raylib.White)
+		engine.DrawRectangle(renderer, player1.Interpolated(alpha), engine.White)
 	}
-	{ // Draw Ball
-		raylib.DrawRectangle(int32(ball.centerPosition.X-(ball.size.X/2)), int32(ball.centerPosition.Y-(ball.size.Y/2)), int32(ball.size.X), int32(ball.size.Y), raylib.White)
+	{ // Draw Balls
+		for _, ball := range balls {
+			engine.DrawRectangle(renderer, ball.Interpolated(alpha), engine.White)
+		}
 	}
-}
-
-func DetectBallTouchesPad(ball Ball, pad *Pad) bool {
-	ballX := ball.centerPosition.X - (ball.size.X / 2)
-	ballY := ball.centerPosition.Y - (ball.size.Y / 2)
-	padX := pad.centerPosition.X - (pad.size.X / 2)
-	padY := pad.centerPosition.Y - (pad.size.Y / 2)
-	if ballY+(ball.size.Y/2) >= padY && ballX >= padX && ballX <= padX+pad.size.X {
-		return true
+	{ // Draw Info
+		hudY := int32(engine.ScreenHeight - 64)
+		engine.DrawText(renderer, "Score "+strconv.Itoa(player1.Score), engine.Left, 15, hudY, 20, engine.LightGray)
+		engine.DrawText(renderer, levels[levelIndex].Name, engine.Center, engine.ScreenWidth/2, hudY, 20, engine.LightGray)
+		engine.DrawText(renderer, "Lives "+strconv.Itoa(numLives), engine.Right, engine.ScreenWidth-15, hudY, 20, engine.LightGray)
+		if multiplier := ComboMultiplier(); multiplier > 1 {
+			engine.DrawText(renderer, "x"+strconv.Itoa(multiplier), engine.Right, engine.ScreenWidth-15, hudY-20, 20, engine.Gold)
+		}
 	}
-	return false
 }
 
-func TypeToColor(typeOf int) raylib.Color {
-	switch typeOf {
-	case 0:
-		return raylib.White
-	case 1:
-		return raylib.Red
-	case 2:
-		return raylib.Green
-	case 3:
-		return raylib.Blue
+// LoseLife takes a life once the last ball is gone and serves a new one, or ends the game.
+func LoseLife() {
+	numLives--
+	combo = 0
+	IsGameOver = numLives <= 0
+	if IsGameOver {
+		return
 	}
-	return raylib.Color{}
+	ResetPowerUps()
+	ResetBalls()
 }
 
-func Max(a float32, b float32) float32 { // Yes Math really doesn't have a max for float32.
-	if a > b {
-		return a
+// ComboMultiplier is what brick scores are multiplied by for the current combo.
+func ComboMultiplier() int {
+	multiplier := 1 + combo/ComboBricksPerMultiplier
+	if multiplier > MaxComboMultiplier {
+		return MaxComboMultiplier
 	}
-	return b
+	return multiplier
 }
 
-func Min(a float32, b float32) float32 {
-	if a < b {
-		return a
+// BrickRectangle returns the screen space bounds of the brick at grid cell (i, j).
+func BrickRectangle(i int, j int) engine.Rectangle {
+	brickX := float32(BrickOffsetX + (i * BrickWidthInPixels))
+	brickY := float32(BrickOffsetY + (j * BrickHeightInPixels))
+	return engine.Rectangle{
+		CenterPosition: engine.Vector2{brickX + (BrickWidthInPixels / 2), brickY + (BrickHeightInPixels / 2)},
+		Size:           engine.Vector2{BrickWidthInPixels, BrickHeightInPixels},
 	}
-	return b
 }
@@ -0,0 +1,120 @@
+package breakout
+
+import (
+	"hackweek/engine"
+	"testing"
+)
+
+const testTickDuration = 1.0 / engine.DefaultTickRate
+
+// startTestGame sets the game up on the bundled levels with the player driven from code.
+func startTestGame(t *testing.T, seed int64) *engine.ProgrammaticInput {
+	var err error
+	levels, err = LoadBundledLevels()
+	if err != nil {
+		t.Fatal(err)
+	}
+	editorLevel = nil
+	rng = engine.NewRand(seed)
+	input := &engine.ProgrammaticInput{}
+	player1.Input = input
+	SetupGame()
+	return input
+}
+
+func countBricks() int {
+	count := 0
+	for i := range bricks {
+		for j := range bricks[i] {
+			if bricks[i][j].isAlive {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func TestBreakoutBreaksBricks(t *testing.T) {
+	input := startTestGame(t, 1)
+	if frame := scenes.DrawFrame(1); !frame.HasText("GO Breakout") {
+		t.Fatal("title screen isn't showing")
+	}
+	scenes.Press(input, engine.Confirm, testTickDuration)
+
+	frame := scenes.DrawFrame(1)
+	if !frame.HasText("Score 0") || !frame.HasText("Lives 3") || !frame.HasText(levels[0].Name) {
+		t.Fatal("HUD isn't drawn once playing")
+	}
+	// Every brick, the pad and the served ball
+	if rectangles := len(frame.CallsOfKind(engine.RectangleCall)); rectangles != countBricks()+2 {
+		t.Fatalf("drew %d rectangles for %d bricks, a pad and a ball", rectangles, countBricks())
+	}
+
+	// Launch and keep the pad under the ball until some bricks are gone
+	bricksAtStart := countBricks()
+	scenes.Press(input, engine.Shoot, testTickDuration)
+	for tick := 0; tick < 60*engine.DefaultTickRate && player1.Score == 0; tick++ {
+		ballX := balls[0].CenterPosition.X
+		input.Set(engine.MoveLeft, ballX < player1.CenterPosition.X-5)
+		input.Set(engine.MoveRight, ballX > player1.CenterPosition.X+5)
+		scenes.Update(testTickDuration)
+	}
+	if player1.Score == 0 || countBricks() >= bricksAtStart {
+		t.Fatal("ball never broke a brick")
+	}
+	if frame := scenes.DrawFrame(1); frame.HasText("Score 0") || len(frame.CallsOfKind(engine.RectangleCall)) >= bricksAtStart+2 {
+		t.Error("score and bricks drawn haven't changed")
+	}
+}
+
+func TestBreakoutGameOver(t *testing.T) {
+	input := startTestGame(t, 2)
+	scenes.Press(input, engine.Confirm, testTickDuration)
+
+	// Serve every ball from the far left and never chase it
+	input.Set(engine.MoveLeft, true)
+	for tick := 0; tick < 300*engine.DefaultTickRate && scenes.Top() == playingScene; tick++ {
+		input.Set(engine.Shoot, tick%2 == 0)
+		scenes.Update(testTickDuration)
+	}
+	input.Set(engine.MoveLeft, false)
+	input.Set(engine.Shoot, false)
+	scenes.Update(testTickDuration)
+
+	frame := scenes.DrawFrame(1)
+	if scenes.Top() != gameOverScene || !frame.HasText("Game Over") || !frame.HasText("Lives 0") {
+		t.Fatalf("want game over drawn over the board, scene is %q", scenes.Top().Name)
+	}
+	if clears := frame.CallsOfKind(engine.ClearCall); len(clears) != 1 || clears[0].Color != levels[0].Background {
+		t.Error("board isn't drawn under the game over overlay")
+	}
+
+	scenes.Press(input, engine.Confirm, testTickDuration)
+	if frame := scenes.DrawFrame(1); scenes.Top() != playingScene || !frame.HasText("Lives 3") {
+		t.Error("Enter didn't start a new game")
+	}
+	if player1.Rectangle.Size != (engine.Vector2{PadWidth, 5}) {
+		t.Error("new game didn't reset the pad")
+	}
+}
+
+func TestSplitBallsLeavesStuckBalls(t *testing.T) {
+	input := startTestGame(t, 1)
+	scenes.Press(input, engine.Confirm, testTickDuration)
+	scenes.Press(input, engine.Shoot, testTickDuration)
+
+	// One ball in flight and one caught by a sticky pad
+	stuck := &Ball{Ball: balls[0].Ball}
+	stuck.Stick(player1.CenterPosition.X)
+	balls = append(balls, stuck)
+
+	SplitBalls()
+	if len(balls) != 4 {
+		t.Fatalf("got %d balls, want the moving one split in three and the stuck one", len(balls))
+	}
+	for _, ball := range balls {
+		if !ball.isStuck && engine.Vector2Length(ball.Velocity) == 0 {
+			t.Errorf("ball at %v isn't stuck and isn't moving", ball.CenterPosition)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package breakout
+
+import "hackweek/engine"
+
+// BrickDefinition is how a Brick.typeOf behaves.
+type BrickDefinition struct {
+	HitPoints   int            // Hits to destroy, 0 for indestructible
+	Colors      []engine.Color // Colors[n-1] is drawn with n hit points left, Colors[0] for indestructible bricks
+	Score       int            // Awarded when destroyed
+	IsExplosive bool           // Destroys the surrounding bricks when it dies
+}
+
+// BrickDefinitions is indexed by Brick.typeOf, which is the digit used in level files.
+var BrickDefinitions = []BrickDefinition{
+	{HitPoints: 1, Colors: []engine.Color{engine.White}, Score: 10},
+	{HitPoints: 1, Colors: []engine.Color{engine.Red}, Score: 20},
+	{HitPoints: 1, Colors: []engine.Color{engine.Green}, Score: 30},
+	{HitPoints: 1, Colors: []engine.Color{engine.Blue}, Score: 40},
+	{HitPoints: 2, Colors: []engine.Color{engine.SkyBlue, engine.DarkBlue}, Score: 60},
+	{HitPoints: 3, Colors: []engine.Color{engine.Pink, engine.Magenta, engine.DarkPurple}, Score: 100},
+	{HitPoints: 0, Colors: []engine.Color{engine.Gray}},
+	{HitPoints: 1, Colors: []engine.Color{engine.Orange}, Score: 50, IsExplosive: true},
+}
+
+var NumBrickTypes = len(BrickDefinitions)
+
+// NumRandomBrickTypes are the plain types a '?' cell picks from.
+const NumRandomBrickTypes = 4
+
+type Brick struct {
+	typeOf    int
+	isAlive   bool
+	hitPoints int
+}
+
+func NewBrick(typeOf int) *Brick {
+	return &Brick{typeOf: typeOf, isAlive: true, hitPoints: BrickDefinitions[typeOf].HitPoints}
+}
+
+func (brick *Brick) Definition() BrickDefinition {
+	return BrickDefinitions[brick.typeOf]
+}
+
+func (brick *Brick) IsIndestructible() bool {
+	return brick.Definition().HitPoints == 0
+}
+
+// Color fades as a multi hit brick weakens.
+func (brick *Brick) Color() engine.Color {
+	colors := brick.Definition().Colors
+	if brick.hitPoints <= 0 {
+		return colors[0]
+	}
+	return colors[brick.hitPoints-1]
+}
+
+// HitBrick takes a hit point off the brick at (i, j), destroying it when it runs out.
+func HitBrick(i int, j int) {
+	brick := bricks[i][j]
+	if !brick.isAlive || brick.IsIndestructible() {
+		return
+	}
+	brick.hitPoints--
+	if brick.hitPoints <= 0 {
+		DestroyBrick(i, j)
+	}
+}
+
+// DestroyBrick kills the brick at (i, j) outright and scores it. An explosive
+// brick takes its eight neighbours with it, which can chain into more explosions.
+func DestroyBrick(i int, j int) {
+	brick := bricks[i][j]
+	if !brick.isAlive || brick.IsIndestructible() {
+		return
+	}
+	brick.isAlive = false
+	brick.hitPoints = 0
+	player1.Score += brick.Definition().Score * ComboMultiplier()
+	combo++
+	MaybeDropCapsule(BrickRectangle(i, j).CenterPosition)
+
+	if brick.Definition().IsExplosive {
+		for x := i - 1; x <= i+1; x++ {
+			for y := j - 1; y <= j+1; y++ {
+				if x >= 0 && x < len(bricks) && y >= 0 && y < len(bricks[x]) {
+					DestroyBrick(x, y)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,10 @@
+package main
+
+import (
+	"hackweek/breakout"
+	"hackweek/engine/backend"
+)
+
+func main() {
+	breakout.Main(backend.Raylib{})
+}
@@ -0,0 +1,193 @@
+package breakout
+
+import (
+	"errors"
+	"hackweek/engine"
+	"io/fs"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	NewLevelWidth        = 12
+	NewLevelHeight       = 8
+	EditorMessageSeconds = 3
+	EditorHelpText       = "Click: next brick  Right click: previous  Arrows: resize  Tab: play-test  S: save  L: reload"
+	EditorPlayTestHelp   = "Tab: back to the editor"
+)
+
+var editorInput engine.Pointer // Set up in Main, like player1.Input
+var editorLevel *Level
+var editorFile string
+var editorMessage string
+var m_TimerEditorMessage float32
+
+// OpenEditor starts the editor on filename, or on a new empty level when the file doesn't exist yet.
+func OpenEditor(filename string) error {
+	level, err := LoadLevel(filename)
+	if errors.Is(err, fs.ErrNotExist) {
+		name := strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
+		level, err = NewLevel(name, NewLevelWidth, NewLevelHeight), nil
+	}
+	if err != nil {
+		return err
+	}
+	editorLevel = level
+	editorFile = filename
+	levels = []*Level{editorLevel}
+	return nil
+}
+
+func UpdateEditor(deltaTime float32) {
+	editorInput.Update()
+	engine.HasHitTime(&m_TimerEditorMessage, deltaTime)
+
+	{ // Cells
+		i, j, ok := EditorCellAt(editorInput.Position())
+		if ok && editorInput.IsPressed(engine.PointerPrimary) {
+			editorLevel.Cells[i][j] = CycleBrickType(editorLevel.Cells[i][j], 1)
+		}
+		if ok && editorInput.IsPressed(engine.PointerSecondary) {
+			editorLevel.Cells[i][j] = CycleBrickType(editorLevel.Cells[i][j], -1)
+		}
+	}
+	{ // Size
+		width, height := editorLevel.Width, editorLevel.Height
+		if editorInput.IsPressed(engine.MoveRight) && width < MaxBoardWidthInBricks {
+			width++
+		}
+		if editorInput.IsPressed(engine.MoveLeft) && width > 1 {
+			width--
+		}
+		if editorInput.IsPressed(engine.MoveDown) && height < MaxBoardHeightInBricks {
+			height++
+		}
+		if editorInput.IsPressed(engine.MoveUp) && height > 1 {
+			height--
+		}
+		if width != editorLevel.Width || height != editorLevel.Height {
+			editorLevel.Resize(width, height)
+		}
+	}
+	{ // Files
+		if editorInput.IsPressed(engine.Save) {
+			if !editorLevel.HasBreakableBrick() {
+				ShowEditorMessage("Add a brick that can be broken to save")
+			} else if err := SaveLevel(editorFile, editorLevel); err != nil {
+				ShowEditorMessage(err.Error())
+			} else {
+				ShowEditorMessage("Saved " + editorFile)
+			}
+		}
+		if editorInput.IsPressed(engine.Load) {
+			if level, err := LoadLevel(editorFile); err != nil {
+				ShowEditorMessage(err.Error())
+			} else {
+				editorLevel = level
+				ShowEditorMessage("Loaded " + editorFile)
+			}
+		}
+	}
+	{ // Play-test
+		if editorInput.IsPressed(engine.SwitchMode) {
+			if !editorLevel.HasBreakableBrick() {
+				ShowEditorMessage("Add a brick that can be broken to play-test")
+				return
+			}
+			levels = []*Level{editorLevel}
+			StartGame()
+			scenes.Push(playTestScene)
+		}
+	}
+}
+
+// UpdatePlayTest plays the level being edited until Tab goes back to the editor.
+func UpdatePlayTest(deltaTime float32) {
+	editorInput.Update()
+	if editorInput.IsPressed(engine.SwitchMode) {
+		scenes.Pop()
+		return
+	}
+	Update(deltaTime)
+}
+
+func DrawPlayTest(renderer engine.Renderer, alpha float32) {
+	Draw(renderer, alpha)
+	engine.DrawText(renderer, EditorPlayTestHelp, engine.Right, engine.ScreenWidth-15, engine.ScreenHeight-30, 10, engine.LightGray)
+}
+
+func ShowEditorMessage(message string) {
+	editorMessage = message
+	m_TimerEditorMessage = EditorMessageSeconds
+}
+
+// EditorCellAt returns the cell of the level being edited under position.
+func EditorCellAt(position engine.Vector2) (i int, j int, ok bool) {
+	i = int(math.Floor(float64((position.X - BrickOffsetX) / BrickWidthInPixels)))
+	j = int(math.Floor(float64((position.Y - BrickOffsetY) / BrickHeightInPixels)))
+	ok = i >= 0 && i < editorLevel.Width && j >= 0 && j < editorLevel.Height
+	return i, j, ok
+}
+
+// CycleBrickType steps a cell through no brick, every brick type and then random, wrapping around.
+func CycleBrickType(cell int, step int) int {
+	// Lay the cells out as 0 for no brick, 1 to NumBrickTypes for the types, then random
+	index := cell + 1
+	if cell == RandomBrick {
+		index = NumBrickTypes + 1
+	}
+	count := NumBrickTypes + 2
+	index = ((index+step)%count + count) % count
+
+	switch index {
+	case 0:
+		return NoBrick
+	case NumBrickTypes + 1:
+		return RandomBrick
+	default:
+		return index - 1
+	}
+}
+
+func DrawEditor(renderer engine.Renderer, alpha float32) {
+	renderer.Clear(editorLevel.Background)
+
+	{ // Draw cells
+		for i := 0; i < editorLevel.Width; i++ {
+			for j := 0; j < editorLevel.Height; j++ {
+				r := BrickRectangle(i, j)
+				min := r.Min()
+				switch cell := editorLevel.Cells[i][j]; cell {
+				case NoBrick:
+					renderer.DrawRectangle(int32(min.X)+1, int32(min.Y)+1, int32(r.Size.X)-2, int32(r.Size.Y)-2, engine.NewColor(40, 40, 40, 255))
+				case RandomBrick:
+					renderer.DrawRectangle(int32(min.X)+1, int32(min.Y)+1, int32(r.Size.X)-2, int32(r.Size.Y)-2, engine.DarkGray)
+					engine.DrawText(renderer, "?", engine.Center, int32(r.CenterPosition.X), int32(min.Y)+2, 20, engine.White)
+				default:
+					renderer.DrawRectangle(int32(min.X)+1, int32(min.Y)+1, int32(r.Size.X)-2, int32(r.Size.Y)-2, NewBrick(cell).Color())
+					engine.DrawText(renderer, strconv.Itoa(cell), engine.Center, int32(r.CenterPosition.X), int32(min.Y)+2, 20, engine.Black)
+				}
+			}
+		}
+	}
+	{ // Draw cell under the mouse
+		if i, j, ok := EditorCellAt(editorInput.Position()); ok {
+			r := BrickRectangle(i, j)
+			min, max := r.Min(), r.Max()
+			renderer.DrawLine(min, engine.Vector2{max.X, min.Y}, 2, engine.Yellow)
+			renderer.DrawLine(engine.Vector2{max.X, min.Y}, max, 2, engine.Yellow)
+			renderer.DrawLine(max, engine.Vector2{min.X, max.Y}, 2, engine.Yellow)
+			renderer.DrawLine(engine.Vector2{min.X, max.Y}, min, 2, engine.Yellow)
+		}
+	}
+	{ // Draw Info
+		size := strconv.Itoa(editorLevel.Width) + "x" + strconv.Itoa(editorLevel.Height)
+		engine.DrawText(renderer, editorFile+"  "+size, engine.Left, 15, engine.ScreenHeight-60, 20, engine.LightGray)
+		engine.DrawText(renderer, EditorHelpText, engine.Left, 15, engine.ScreenHeight-30, 10, engine.LightGray)
+		if m_TimerEditorMessage > 0 {
+			engine.DrawText(renderer, editorMessage, engine.Right, engine.ScreenWidth-15, engine.ScreenHeight-60, 20, engine.Yellow)
+		}
+	}
+}
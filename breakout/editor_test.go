@@ -0,0 +1,51 @@
+package breakout
+
+import (
+	"errors"
+	"hackweek/engine"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEditorPlayTest(t *testing.T) {
+	rng = engine.NewRand(1)
+	player1.Input = &engine.ProgrammaticInput{}
+	input := &engine.ProgrammaticInput{}
+	editorInput = input
+	filename := filepath.Join(t.TempDir(), "new.txt")
+	if err := OpenEditor(filename); err != nil {
+		t.Fatal(err)
+	}
+	SetupGame()
+
+	scenes.Press(input, engine.SwitchMode, testTickDuration)
+	if scenes.Top() != editorScene || m_TimerEditorMessage <= 0 {
+		t.Fatalf("play-tested an empty level, scene %q", scenes.Top().Name)
+	}
+	scenes.Press(input, engine.Save, testTickDuration)
+	if _, err := os.Stat(filename); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("saved an empty level that can't be loaded again: %v", err)
+	}
+
+	// Click the top left cell up to a plain brick
+	input.SetPosition(BrickRectangle(0, 0).CenterPosition)
+	scenes.Press(input, engine.PointerPrimary, testTickDuration)
+	if cell := editorLevel.Cells[0][0]; cell != 0 {
+		t.Fatalf("clicked cell is %d, want brick 0", cell)
+	}
+
+	scenes.Press(input, engine.SwitchMode, testTickDuration)
+	if scenes.Top() != playTestScene {
+		t.Fatalf("scene is %q, want play-test", scenes.Top().Name)
+	}
+	scenes.Press(input, engine.SwitchMode, testTickDuration)
+	if scenes.Top() != editorScene {
+		t.Fatalf("scene is %q, want editor", scenes.Top().Name)
+	}
+	scenes.Press(input, engine.Save, testTickDuration)
+	if _, err := LoadLevel(filename); err != nil {
+		t.Fatalf("saved level doesn't load: %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+package breakout
+
+import (
+	"hackweek/engine"
+	"math"
+	"sort"
+)
+
+// BrickHit is a swept collision with the brick at grid cell (I, J).
+type BrickHit struct {
+	engine.Hit
+	I, J int
+}
+
+// BrickCellRange returns the columns and rows of the brick cells touched by r,
+// clamped to the board. The range is empty (min > max) when r is off the board.
+func BrickCellRange(r engine.Rectangle) (minI int, minJ int, maxI int, maxJ int) {
+	if len(bricks) == 0 {
+		return 0, 0, -1, -1
+	}
+	min, max := r.Min(), r.Max()
+	minI = maxInt(brickCell(min.X-BrickOffsetX, BrickWidthInPixels), 0)
+	maxI = minInt(brickCell(max.X-BrickOffsetX, BrickWidthInPixels), len(bricks)-1)
+	minJ = maxInt(brickCell(min.Y-BrickOffsetY, BrickHeightInPixels), 0)
+	maxJ = minInt(brickCell(max.Y-BrickOffsetY, BrickHeightInPixels), len(bricks[0])-1)
+	return minI, minJ, maxI, maxJ
+}
+
+// brickCell is the cell offset pixels from the board's corner falls in, which can be outside the board.
+func brickCell(offset float32, cellSize float32) int {
+	return int(math.Floor(float64(offset / cellSize)))
+}
+
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// SweepBricks returns every live brick moving would hit along movement, nearest
+// first. Only the cells covered by the whole movement are tested, so the cost
+// depends on how far the rectangle moves and not on the size of the board.
+// Hits at the same time are ordered by distance from the mover and then by cell
+// so the result never depends on the order cells were visited in.
+func SweepBricks(moving engine.Rectangle, movement engine.Vector2) []BrickHit {
+	moved := moving
+	moved.CenterPosition = engine.Vector2Add(moving.CenterPosition, movement)
+	swept := engine.Rectangle{
+		CenterPosition: engine.Vector2Scale(engine.Vector2Add(moving.CenterPosition, moved.CenterPosition), 0.5),
+		Size:           engine.Vector2{moving.Size.X + engine.Max(movement.X, -movement.X), moving.Size.Y + engine.Max(movement.Y, -movement.Y)},
+	}
+
+	var hits []BrickHit
+	minI, minJ, maxI, maxJ := BrickCellRange(swept)
+	for i := minI; i <= maxI; i++ {
+		for j := minJ; j <= maxJ; j++ {
+			if !bricks[i][j].isAlive {
+				continue
+			}
+			if hit, ok := engine.SweptAABB(moving, movement, BrickRectangle(i, j)); ok {
+				hits = append(hits, BrickHit{Hit: hit, I: i, J: j})
+			}
+		}
+	}
+
+	sort.Slice(hits, func(a int, b int) bool {
+		if hits[a].Time != hits[b].Time {
+			return hits[a].Time < hits[b].Time
+		}
+		distanceA := engine.Vector2Distance(moving.CenterPosition, BrickRectangle(hits[a].I, hits[a].J).CenterPosition)
+		distanceB := engine.Vector2Distance(moving.CenterPosition, BrickRectangle(hits[b].I, hits[b].J).CenterPosition)
+		if distanceA != distanceB {
+			return distanceA < distanceB
+		}
+		if hits[a].I != hits[b].I {
+			return hits[a].I < hits[b].I
+		}
+		return hits[a].J < hits[b].J
+	})
+	return hits
+}
+
+// FirstBrickHits returns the bricks moving hits first along movement. More than
+// one comes back when it reaches several at once, e.g. on the seam between two.
+func FirstBrickHits(moving engine.Rectangle, movement engine.Vector2) []BrickHit {
+	hits := SweepBricks(moving, movement)
+	for n := range hits {
+		if hits[n].Time != hits[0].Time {
+			return hits[:n]
+		}
+	}
+	return hits
+}
@@ -0,0 +1,129 @@
+package breakout
+
+import (
+	"hackweek/engine"
+	"math/rand"
+	"testing"
+)
+
+const benchmarkBoardSize = 256
+
+// scanBricks is the old collision pass: sweep against every cell on the board and keep the nearest.
+func scanBricks(moving engine.Rectangle, movement engine.Vector2) (BrickHit, bool) {
+	nearest := BrickHit{I: -1, J: -1}
+	for i := range bricks {
+		for j := range bricks[i] {
+			if !bricks[i][j].isAlive {
+				continue
+			}
+			hit, ok := engine.SweptAABB(moving, movement, BrickRectangle(i, j))
+			if ok && (nearest.I < 0 || hit.Time < nearest.Time) {
+				nearest = BrickHit{Hit: hit, I: i, J: j}
+			}
+		}
+	}
+	return nearest, nearest.I >= 0
+}
+
+// setUpBenchmarkBoard fills a large board with every other brick alive and
+// returns ball movements scattered across it.
+func setUpBenchmarkBoard() ([]engine.Rectangle, []engine.Vector2) {
+	bricks = make([][]*Brick, benchmarkBoardSize)
+	for i := range bricks {
+		bricks[i] = make([]*Brick, benchmarkBoardSize)
+		for j := range bricks[i] {
+			bricks[i][j] = &Brick{}
+			if (i+j)%2 == 0 {
+				bricks[i][j] = NewBrick(0)
+			}
+		}
+	}
+
+	random := rand.New(rand.NewSource(1))
+	balls := make([]engine.Rectangle, 1024)
+	movements := make([]engine.Vector2, len(balls))
+	for n := range balls {
+		balls[n] = engine.Rectangle{
+			CenterPosition: engine.Vector2{random.Float32() * benchmarkBoardSize * BrickWidthInPixels, random.Float32() * benchmarkBoardSize * BrickHeightInPixels},
+			Size:           engine.Vector2{10, 10},
+		}
+		movements[n] = engine.Vector2{random.Float32()*20 - 10, random.Float32()*20 - 10}
+	}
+	return balls, movements
+}
+
+func BenchmarkBrickCollisionScan(b *testing.B) {
+	balls, movements := setUpBenchmarkBoard()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		scanBricks(balls[n%len(balls)], movements[n%len(movements)])
+	}
+}
+
+func BenchmarkBrickCollisionGrid(b *testing.B) {
+	balls, movements := setUpBenchmarkBoard()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		FirstBrickHits(balls[n%len(balls)], movements[n%len(movements)])
+	}
+}
+
+func TestFirstBrickHitsMatchesScan(t *testing.T) {
+	balls, movements := setUpBenchmarkBoard()
+	for n := range balls {
+		want, wantOk := scanBricks(balls[n], movements[n])
+		hits := FirstBrickHits(balls[n], movements[n])
+		if len(hits) > 0 != wantOk {
+			t.Fatalf("sample %d: grid found %d hits, scan found a hit: %v", n, len(hits), wantOk)
+		}
+		if !wantOk {
+			continue
+		}
+		// The scan keeps only the first of several hits at the same time, the grid returns them all
+		isFound := false
+		for _, hit := range hits {
+			if hit.Time != want.Time {
+				t.Fatalf("sample %d: grid hit %+v at a different time to scan hit %+v", n, hit, want)
+			}
+			isFound = isFound || hit == want
+		}
+		if !isFound {
+			t.Fatalf("sample %d: scan hit %+v missing from grid hits %+v", n, want, hits)
+		}
+	}
+}
+
+func TestFirstBrickHitsOnSeam(t *testing.T) {
+	// Two bricks side by side on the top row, with the ball coming up from below
+	bricks = [][]*Brick{{NewBrick(0), &Brick{}}, {NewBrick(0), &Brick{}}, {&Brick{}, &Brick{}}}
+	seamX := BrickRectangle(0, 0).Max().X
+	belowY := BrickRectangle(0, 1).CenterPosition.Y
+
+	tests := []struct {
+		name    string
+		offsetX float32
+		want    [][2]int
+	}{
+		{"centred on the seam", 0, [][2]int{{0, 0}, {1, 0}}},
+		{"mostly under the left brick", -2, [][2]int{{0, 0}, {1, 0}}},
+		{"mostly under the right brick", 2, [][2]int{{1, 0}, {0, 0}}},
+		{"clear of the seam", 20, [][2]int{{1, 0}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ball := engine.Rectangle{CenterPosition: engine.Vector2{seamX + test.offsetX, belowY}, Size: engine.Vector2{10, 10}}
+			hits := FirstBrickHits(ball, engine.Vector2{0, -BrickHeightInPixels})
+			if len(hits) != len(test.want) {
+				t.Fatalf("got %d hits %+v, want %v", len(hits), hits, test.want)
+			}
+			for n, hit := range hits {
+				if [2]int{hit.I, hit.J} != test.want[n] {
+					t.Errorf("hit %d is brick (%d, %d), want %v", n, hit.I, hit.J, test.want[n])
+				}
+				if hit.Normal != (engine.Vector2{0, 1}) {
+					t.Errorf("hit %d has normal %v, want straight down", n, hit.Normal)
+				}
+			}
+		})
+	}
+}
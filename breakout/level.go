@@ -0,0 +1,301 @@
+package breakout
+
+import (
+	"bufio"
+	"embed"
+	"fmt"
+	"hackweek/engine"
+	"io"
+	"math"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// A level file is a few "key: value" lines followed by the brick grid:
+//
+//	# Comments and blank lines are ignored above the grid
+//	name: Classic
+//	size: 12x13
+//	ballspeed: 56
+//	background: #000000
+//	grid:
+//	0123....3210
+//	????????????
+//
+// size and grid are required. Each grid row is one row of bricks, each
+// character one brick: a digit is its type in BrickDefinitions, '.' is no
+// brick and '?' is a random plain type. There has to be at least one brick
+// that can be broken.
+
+const (
+	NoBrick     = -1
+	RandomBrick = -2
+)
+
+const (
+	MaxBoardWidthInBricks  = 12
+	MaxBoardHeightInBricks = 15
+	DefaultBallSpeed       = 56
+)
+
+type Level struct {
+	Name       string
+	Width      int
+	Height     int
+	Cells      [][]int // Cells[i][j] is the brick type at column i, row j
+	BallSpeed  float32
+	Background engine.Color
+}
+
+//go:embed levels/*.txt
+var bundledLevels embed.FS
+
+// LoadBundledLevels returns the levels shipped in the levels directory, in file name order.
+func LoadBundledLevels() ([]*Level, error) {
+	entries, err := bundledLevels.ReadDir("levels")
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+
+	levels := make([]*Level, 0, len(names))
+	for _, name := range names {
+		file, err := bundledLevels.Open(path.Join("levels", name))
+		if err != nil {
+			return nil, err
+		}
+		level, err := ParseLevel(name, file)
+		file.Close()
+		if err != nil {
+			return nil, err
+		}
+		levels = append(levels, level)
+	}
+	return levels, nil
+}
+
+// NewLevel returns an empty board of the given size with the default settings.
+func NewLevel(name string, width int, height int) *Level {
+	level := &Level{Name: name, BallSpeed: DefaultBallSpeed, Background: engine.Black}
+	level.Resize(width, height)
+	return level
+}
+
+// Resize changes the size of the board, keeping the bricks that still fit and leaving new cells empty.
+func (level *Level) Resize(width int, height int) {
+	cells := make([][]int, width)
+	for i := range cells {
+		cells[i] = make([]int, height)
+		for j := range cells[i] {
+			cells[i][j] = NoBrick
+			if i < level.Width && j < level.Height {
+				cells[i][j] = level.Cells[i][j]
+			}
+		}
+	}
+	level.Width, level.Height, level.Cells = width, height, cells
+}
+
+// HasBreakableBrick reports whether the level has anything to clear. Without
+// one the level would count as cleared as soon as it started.
+func (level *Level) HasBreakableBrick() bool {
+	for i := range level.Cells {
+		for _, cell := range level.Cells[i] {
+			if cell == RandomBrick || (cell >= 0 && BrickDefinitions[cell].HitPoints > 0) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func LoadLevel(filename string) (*Level, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ParseLevel(filename, file)
+}
+
+// ParseLevel reads a level, reporting the first problem as a *engine.ParseError. filename is only used in errors.
+func ParseLevel(filename string, r io.Reader) (*Level, error) {
+	level := &Level{BallSpeed: DefaultBallSpeed, Background: engine.Black}
+	fail := func(line int, column int, format string, args ...interface{}) (*Level, error) {
+		return nil, &engine.ParseError{File: filename, Line: line, Column: column, Message: fmt.Sprintf(format, args...)}
+	}
+
+	seenKeys := map[string]bool{}
+	gridLine := 0 // Line number of "grid:", 0 until it is found
+	var rows []string
+	var rowLines []int
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		text := strings.TrimRight(scanner.Text(), " \t\r")
+		if gridLine != 0 {
+			if text != "" {
+				rows = append(rows, text)
+				rowLines = append(rowLines, lineNumber)
+			}
+			continue
+		}
+
+		trimmed := strings.TrimSpace(text)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		colon := strings.Index(text, ":")
+		if colon < 0 {
+			return fail(lineNumber, 1, "expected \"key: value\", got %q", trimmed)
+		}
+		key := strings.TrimSpace(text[:colon])
+		value := strings.TrimSpace(text[colon+1:])
+		valueColumn := colon + 2 + (len(text[colon+1:]) - len(strings.TrimLeft(text[colon+1:], " \t")))
+		if seenKeys[key] {
+			return fail(lineNumber, 1, "%s is set twice", key)
+		}
+		seenKeys[key] = true
+
+		switch key {
+		case "name":
+			level.Name = value
+		case "size":
+			width, height, ok := parseSize(value)
+			if !ok {
+				return fail(lineNumber, valueColumn, "size must look like 12x13, got %q", value)
+			}
+			if width < 1 || width > MaxBoardWidthInBricks || height < 1 || height > MaxBoardHeightInBricks {
+				return fail(lineNumber, valueColumn, "size %dx%d is outside 1x1 to %dx%d", width, height, MaxBoardWidthInBricks, MaxBoardHeightInBricks)
+			}
+			level.Width, level.Height = width, height
+		case "ballspeed":
+			speed, err := strconv.ParseFloat(value, 32)
+			if err != nil || math.IsNaN(speed) || math.IsInf(speed, 0) || speed <= 0 {
+				return fail(lineNumber, valueColumn, "ballspeed must be a positive number, got %q", value)
+			}
+			level.BallSpeed = float32(speed)
+		case "background":
+			color, ok := parseHexColor(value)
+			if !ok {
+				return fail(lineNumber, valueColumn, "background must look like #RRGGBB, got %q", value)
+			}
+			level.Background = color
+		case "grid":
+			if value != "" {
+				return fail(lineNumber, valueColumn, "the grid starts on the line after \"grid:\"")
+			}
+			gridLine = lineNumber
+		default:
+			return fail(lineNumber, 1, "unknown key %q", key)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if level.Width == 0 {
+		return fail(lineNumber+1, 1, "missing size")
+	}
+	if gridLine == 0 {
+		return fail(lineNumber+1, 1, "missing grid")
+	}
+	if len(rows) != level.Height {
+		return fail(gridLine, 1, "grid has %d rows, size says %d", len(rows), level.Height)
+	}
+
+	level.Cells = make([][]int, level.Width)
+	for i := range level.Cells {
+		level.Cells[i] = make([]int, level.Height)
+	}
+	for j, row := range rows {
+		if len(row) != level.Width {
+			return fail(rowLines[j], 1, "row has %d bricks, size says %d", len(row), level.Width)
+		}
+		for i, cell := range []byte(row) {
+			switch {
+			case cell == '.':
+				level.Cells[i][j] = NoBrick
+			case cell == '?':
+				level.Cells[i][j] = RandomBrick
+			case cell >= '0' && cell <= '9' && int(cell-'0') < NumBrickTypes:
+				level.Cells[i][j] = int(cell - '0')
+			default:
+				return fail(rowLines[j], i+1, "unknown brick %q, want '.', '?' or 0 to %d", cell, NumBrickTypes-1)
+			}
+		}
+	}
+	if !level.HasBreakableBrick() {
+		return fail(gridLine, 1, "grid has no bricks that can be broken")
+	}
+	return level, nil
+}
+
+// WriteLevel writes level in the format ParseLevel reads.
+func WriteLevel(w io.Writer, level *Level) error {
+	var b strings.Builder
+	if level.Name != "" {
+		fmt.Fprintf(&b, "name: %s\n", level.Name)
+	}
+	fmt.Fprintf(&b, "size: %dx%d\n", level.Width, level.Height)
+	fmt.Fprintf(&b, "ballspeed: %s\n", strconv.FormatFloat(float64(level.BallSpeed), 'g', -1, 32))
+	fmt.Fprintf(&b, "background: #%02x%02x%02x\n", level.Background.R, level.Background.G, level.Background.B)
+	b.WriteString("grid:\n")
+	for j := 0; j < level.Height; j++ {
+		for i := 0; i < level.Width; i++ {
+			switch cell := level.Cells[i][j]; cell {
+			case NoBrick:
+				b.WriteByte('.')
+			case RandomBrick:
+				b.WriteByte('?')
+			default:
+				b.WriteByte(byte('0' + cell))
+			}
+		}
+		b.WriteByte('\n')
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func SaveLevel(filename string, level *Level) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	if err := WriteLevel(file, level); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func parseSize(value string) (width int, height int, ok bool) {
+	parts := strings.Split(value, "x")
+	if len(parts) != 2 {
+		return 0, 0, false
+	}
+	width, widthErr := strconv.Atoi(strings.TrimSpace(parts[0]))
+	height, heightErr := strconv.Atoi(strings.TrimSpace(parts[1]))
+	return width, height, widthErr == nil && heightErr == nil
+}
+
+func parseHexColor(value string) (engine.Color, bool) {
+	if len(value) != 7 || value[0] != '#' {
+		return engine.Color{}, false
+	}
+	rgb, err := strconv.ParseUint(value[1:], 16, 32)
+	if err != nil {
+		return engine.Color{}, false
+	}
+	return engine.NewColor(uint8(rgb>>16), uint8(rgb>>8), uint8(rgb), 255), true
+}
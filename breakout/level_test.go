@@ -0,0 +1,62 @@
+package breakout
+
+import (
+	"errors"
+	"hackweek/engine"
+	"strings"
+	"testing"
+)
+
+func TestParseLevelErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		line   int
+		column int
+	}{
+		{"not a key", "size 2x1\n", 1, 1},
+		{"unknown key", "# Comment\nspeed: 3\n", 2, 1},
+		{"key twice", "name: A\nname: B\n", 2, 1},
+		{"bad size", "size:  two\n", 1, 8},
+		{"size too big", "size: 13x1\n", 1, 7},
+		{"bad ball speed", "ballspeed: -1\n", 1, 12},
+		{"ball speed not a number", "ballspeed: NaN\n", 1, 12},
+		{"endless ball speed", "ballspeed: Inf\n", 1, 12},
+		{"bad background", "background: red\n", 1, 13},
+		{"grid on its line", "size: 2x1\ngrid: 00\n", 2, 7},
+		{"missing size", "grid:\n00\n", 3, 1},
+		{"missing grid", "size: 2x1\n", 2, 1},
+		{"wrong row count", "size: 2x2\ngrid:\n00\n", 2, 1},
+		{"short row", "size: 3x2\ngrid:\n000\n00\n", 4, 1},
+		{"unknown brick", "size: 3x1\ngrid:\n0x0\n", 3, 2},
+		{"nothing to break", "size: 3x1\ngrid:\n.6.\n", 2, 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParseLevel("test.txt", strings.NewReader(test.text))
+			var parseError *engine.ParseError
+			if !errors.As(err, &parseError) {
+				t.Fatalf("got %v, want a *engine.ParseError", err)
+			}
+			if parseError.File != "test.txt" || parseError.Line != test.line || parseError.Column != test.column {
+				t.Errorf("got %v, want test.txt:%d:%d", err, test.line, test.column)
+			}
+		})
+	}
+}
+
+func TestParseBundledLevels(t *testing.T) {
+	levels, err := LoadBundledLevels()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, level := range levels {
+		var b strings.Builder
+		if err := WriteLevel(&b, level); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ParseLevel(level.Name, strings.NewReader(b.String())); err != nil {
+			t.Errorf("level %q doesn't read back after writing: %v", level.Name, err)
+		}
+	}
+}
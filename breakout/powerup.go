@@ -0,0 +1,201 @@
+package breakout
+
+import (
+	"hackweek/engine"
+	"strconv"
+)
+
+type PowerUpKind int
+
+const (
+	WiderPad PowerUpKind = iota
+	SlowerBall
+	Multiball
+	StickyPad
+	LaserPad
+	ExtraLife
+	NumPowerUpKinds
+)
+
+type PowerUpDefinition struct {
+	Name       string
+	Letter     string // Drawn on the falling capsule
+	Color      engine.Color
+	Duration   float32 // Seconds it lasts, 0 for instant ones
+	DropWeight int     // Relative chance of being picked when a brick drops a capsule
+}
+
+// PowerUpDefinitions is indexed by PowerUpKind.
+var PowerUpDefinitions = [NumPowerUpKinds]PowerUpDefinition{
+	WiderPad:   {Name: "Wide", Letter: "W", Color: engine.SkyBlue, Duration: 10, DropWeight: 3},
+	SlowerBall: {Name: "Slow", Letter: "S", Color: engine.Lime, Duration: 8, DropWeight: 3},
+	Multiball:  {Name: "Multi", Letter: "M", Color: engine.Gold, DropWeight: 2},
+	StickyPad:  {Name: "Sticky", Letter: "C", Color: engine.Purple, Duration: 12, DropWeight: 2},
+	LaserPad:   {Name: "Laser", Letter: "L", Color: engine.Red, Duration: 8, DropWeight: 2},
+	ExtraLife:  {Name: "Life", Letter: "+", Color: engine.Pink, DropWeight: 1},
+}
+
+const (
+	PowerUpDropChance       = 0.15
+	CapsuleFallSpeed        = 80
+	PadWidth                = 50
+	WidePadScale            = 1.6
+	SlowBallTimeScale       = 0.6 // The ball runs at this fraction of game time while slowed
+	LaserCooldownSeconds    = 0.25
+	LaserSpeed              = 500
+	StickyPadMaxHoldSeconds = 5 // Seconds before a stuck ball launches by itself
+)
+
+type Capsule struct {
+	engine.Mover
+	kind PowerUpKind
+}
+
+type Laser struct {
+	engine.Mover
+}
+
+var capsules []*Capsule
+var lasers []*Laser
+var powerUpTimers [NumPowerUpKinds]float32 // Seconds left on each timed power-up
+var m_TimerLaserCooldown float32
+
+func IsPowerUpActive(kind PowerUpKind) bool {
+	return powerUpTimers[kind] > 0
+}
+
+// ResetPowerUps clears everything falling or active, e.g. for a new level.
+func ResetPowerUps() {
+	capsules = nil
+	lasers = nil
+	powerUpTimers = [NumPowerUpKinds]float32{}
+	player1.Size.X = PadWidth
+}
+
+// MaybeDropCapsule sometimes drops a random power-up from where a brick died.
+func MaybeDropCapsule(position engine.Vector2) {
+	if rng.Float32() >= PowerUpDropChance {
+		return
+	}
+	totalWeight := 0
+	for _, definition := range PowerUpDefinitions {
+		totalWeight += definition.DropWeight
+	}
+	pick := rng.Intn(totalWeight)
+	for kind, definition := range PowerUpDefinitions {
+		if pick < definition.DropWeight {
+			capsule := &Capsule{kind: PowerUpKind(kind)}
+			capsule.Size = engine.Vector2{30, 12}
+			capsule.Teleport(position)
+			capsules = append(capsules, capsule)
+			return
+		}
+		pick -= definition.DropWeight
+	}
+}
+
+func ApplyPowerUp(kind PowerUpKind) {
+	powerUpTimers[kind] = PowerUpDefinitions[kind].Duration
+	switch kind {
+	case Multiball:
+		SplitBalls()
+	case ExtraLife:
+		numLives++
+	}
+}
+
+func UpdatePowerUps(deltaTime float32) {
+	{ // Timers
+		for kind := range powerUpTimers {
+			powerUpTimers[kind] = engine.Max(powerUpTimers[kind]-deltaTime, 0)
+		}
+		if !IsPowerUpActive(StickyPad) {
+			for _, ball := range balls {
+				if !ball.isServe {
+					ball.Launch()
+				}
+			}
+		}
+	}
+	{ // Pad width
+		player1.Size.X = PadWidth
+		if IsPowerUpActive(WiderPad) {
+			player1.Size.X = PadWidth * WidePadScale
+		}
+		player1.MoveX(0, engine.ScreenWidth)
+	}
+	{ // Falling capsules
+		remaining := capsules[:0]
+		for _, capsule := range capsules {
+			capsule.StartTick()
+			capsule.CenterPosition.Y += CapsuleFallSpeed * deltaTime
+			if capsule.Overlaps(player1.Rectangle) {
+				ApplyPowerUp(capsule.kind)
+				continue
+			}
+			if capsule.Min().Y > engine.ScreenHeight {
+				continue
+			}
+			remaining = append(remaining, capsule)
+		}
+		capsules = remaining
+	}
+	{ // Lasers
+		if IsPowerUpActive(LaserPad) && engine.HasHitTime(&m_TimerLaserCooldown, deltaTime) && player1.Input.IsDown(engine.Shoot) {
+			m_TimerLaserCooldown = LaserCooldownSeconds
+			for _, offsetX := range []float32{-player1.Size.X / 2, player1.Size.X / 2} {
+				laser := &Laser{}
+				laser.Size = engine.Vector2{3, 10}
+				laser.Teleport(engine.Vector2{player1.CenterPosition.X + offsetX, player1.CenterPosition.Y})
+				lasers = append(lasers, laser)
+			}
+		}
+		remaining := lasers[:0]
+		for _, laser := range lasers {
+			if MoveLaser(laser, deltaTime) {
+				remaining = append(remaining, laser)
+			}
+		}
+		lasers = remaining
+	}
+}
+
+// MoveLaser moves a laser up and hits the first brick in its way. It reports whether the laser is still going.
+func MoveLaser(laser *Laser, deltaTime float32) bool {
+	laser.StartTick()
+	movement := engine.Vector2{0, -LaserSpeed * deltaTime}
+	if hits := FirstBrickHits(laser.Rectangle, movement); len(hits) > 0 {
+		HitBrick(hits[0].I, hits[0].J)
+		return false
+	}
+	laser.CenterPosition.Y += movement.Y
+	return laser.Max().Y > 0
+}
+
+func DrawPowerUps(renderer engine.Renderer, alpha float32) {
+	{ // Capsules
+		for _, capsule := range capsules {
+			definition := PowerUpDefinitions[capsule.kind]
+			r := capsule.Interpolated(alpha)
+			engine.DrawRectangle(renderer, r, definition.Color)
+			engine.DrawText(renderer, definition.Letter, engine.Center, int32(r.CenterPosition.X), int32(r.Min().Y+1), 10, engine.Black)
+		}
+	}
+	{ // Lasers
+		for _, laser := range lasers {
+			engine.DrawRectangle(renderer, laser.Interpolated(alpha), engine.Red)
+		}
+	}
+	{ // Active power-ups with the seconds they have left
+		posX := int32(10)
+		for kind, timeLeft := range powerUpTimers {
+			if timeLeft <= 0 {
+				continue
+			}
+			definition := PowerUpDefinitions[kind]
+			text := definition.Name + " " + strconv.Itoa(int(timeLeft+0.99))
+			engine.DrawText(renderer, text, engine.Left, posX, engine.ScreenHeight-40, 10, definition.Color)
+			posX += renderer.MeasureText(text, 10) + 10
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package breakout
+
+import (
+	"hackweek/engine"
+)
+
+var scenes engine.SceneStack
+var titleScene *engine.Scene
+var playingScene *engine.Scene
+var pausedScene *engine.Scene
+var gameOverScene *engine.Scene
+var editorScene *engine.Scene
+var playTestScene *engine.Scene
+
+// SetupScenes builds the game's scenes and starts on the title, or on the editor when one was opened.
+func SetupScenes() {
+	menu := &engine.Menu{
+		Stack:      &scenes,
+		Input:      player1.Input,
+		Title:      "GO Breakout",
+		Controls:   "A/D to move, Space to launch, P to pause",
+		Background: engine.Black,
+		Dim:        engine.NewColor(0, 0, 0, 160),
+		Text:       engine.LightGray,
+		Hint:       engine.Gray,
+	}
+	playingScene = &engine.Scene{Name: "playing", Update: Update, Draw: Draw}
+	titleScene = menu.TitleScene(playingScene, StartGame)
+	pausedScene = menu.PausedScene()
+	gameOverScene = menu.GameOverScene(func() string { return "Game Over" }, "Press Enter to play again", StartGame)
+	editorScene = &engine.Scene{Name: "editor", Update: UpdateEditor, Draw: DrawEditor}
+	playTestScene = &engine.Scene{Name: "play-test", Update: UpdatePlayTest, Draw: DrawPlayTest}
+
+	if editorLevel != nil {
+		scenes.Switch(editorScene)
+	} else {
+		scenes.Switch(titleScene)
+	}
+}
@@ -0,0 +1,46 @@
+// Package backend runs the games in a raylib window, with the real keyboard
+// and mouse. It is the only package that uses raylib, so nothing else needs
+// cgo or a display to build and test.
+package backend
+
+import (
+	"hackweek/engine"
+
+	raylib "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Raylib is the engine.Platform the games run on.
+type Raylib struct{}
+
+func (Raylib) NewKeyboardInput(bindings map[engine.Action]engine.Key) engine.Input {
+	return NewKeyboardInput(bindings)
+}
+
+func (Raylib) NewMouseInput(keys map[engine.Action]engine.Key, buttons map[engine.Action]engine.MouseButton) engine.Pointer {
+	return NewMouseInput(keys, buttons)
+}
+
+func (Raylib) Run(game engine.Game) {
+	Run(game)
+}
+
+func Run(game engine.Game) {
+	raylib.InitWindow(engine.ScreenWidth, engine.ScreenHeight, game.Title)
+	defer raylib.CloseWindow()
+	raylib.SetTargetFPS(engine.TargetFPS)
+
+	renderer := RaylibRenderer{}
+	loop := engine.NewFixedStep(game.TickRate, game.MaxStepsPerFrame)
+	if game.Setup != nil {
+		game.Setup()
+	}
+
+	for !raylib.WindowShouldClose() {
+		dt := raylib.GetFrameTime()
+		alpha := loop.Advance(dt, game.Update)
+
+		raylib.BeginDrawing()
+		game.Draw(renderer, alpha)
+		raylib.EndDrawing()
+	}
+}
@@ -0,0 +1,53 @@
+package backend
+
+import (
+	"hackweek/engine"
+
+	raylib "github.com/gen2brain/raylib-go/raylib"
+)
+
+// KeyboardInput maps actions to keys.
+type KeyboardInput struct {
+	engine.ActionState
+	Bindings map[engine.Action]engine.Key
+}
+
+func NewKeyboardInput(bindings map[engine.Action]engine.Key) *KeyboardInput {
+	return &KeyboardInput{Bindings: bindings}
+}
+
+func (k *KeyboardInput) Update() {
+	var next [engine.ActionCount]bool
+	for action, key := range k.Bindings {
+		next[action] = raylib.IsKeyDown(int32(key))
+	}
+	k.Advance(next)
+}
+
+// MouseInput is a KeyboardInput that can also bind actions to mouse buttons,
+// and points wherever the mouse is.
+type MouseInput struct {
+	KeyboardInput
+	Buttons  map[engine.Action]engine.MouseButton
+	position engine.Vector2
+}
+
+func NewMouseInput(keys map[engine.Action]engine.Key, buttons map[engine.Action]engine.MouseButton) *MouseInput {
+	return &MouseInput{KeyboardInput: KeyboardInput{Bindings: keys}, Buttons: buttons}
+}
+
+func (m *MouseInput) Update() {
+	var next [engine.ActionCount]bool
+	for action, key := range m.Bindings {
+		next[action] = raylib.IsKeyDown(int32(key))
+	}
+	for action, button := range m.Buttons {
+		next[action] = next[action] || raylib.IsMouseButtonDown(int32(button))
+	}
+	m.position = engine.Vector2(raylib.GetMousePosition())
+	m.Advance(next)
+}
+
+func (m *MouseInput) Position() engine.Vector2 {
+	return m.position
+}
@@ -0,0 +1,30 @@
+package backend
+
+import (
+	"hackweek/engine"
+
+	raylib "github.com/gen2brain/raylib-go/raylib"
+)
+
+// RaylibRenderer draws to the open raylib window.
+type RaylibRenderer struct{}
+
+func (RaylibRenderer) Clear(color engine.Color) {
+	raylib.ClearBackground(color)
+}
+
+func (RaylibRenderer) DrawRectangle(posX int32, posY int32, width int32, height int32, color engine.Color) {
+	raylib.DrawRectangle(posX, posY, width, height, color)
+}
+
+func (RaylibRenderer) DrawLine(from engine.Vector2, to engine.Vector2, thickness float32, color engine.Color) {
+	raylib.DrawLineEx(raylib.Vector2(from), raylib.Vector2(to), thickness, color)
+}
+
+func (RaylibRenderer) DrawText(text string, posX int32, posY int32, fontSize int32, color engine.Color) {
+	raylib.DrawText(text, posX, posY, fontSize, color)
+}
+
+func (RaylibRenderer) MeasureText(text string, fontSize int32) int32 {
+	return raylib.MeasureText(text, fontSize)
+}
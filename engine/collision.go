@@ -0,0 +1,129 @@
+package engine
+
+import "math"
+
+// MaxSweepHits caps how many collisions MoveSwept resolves in one call so a
+// ball wedged between two things can't loop forever.
+const MaxSweepHits = 4
+
+// Hit is where along a movement a swept rectangle first touches another.
+type Hit struct {
+	Time   float32 // Fraction of the movement, 0 to 1, before contact
+	Normal Vector2 // Unit normal of the face that was hit, pointing back at the mover
+}
+
+// SweptAABB moves the rectangle by movement and reports the first time it
+// touches target. A rectangle that starts touching or overlapping target only
+// hits, at Time 0, if it is moving into the face it is closest to; one moving
+// away from or sliding along that face never hits.
+func SweptAABB(moving Rectangle, movement Vector2, target Rectangle) (Hit, bool) {
+	if movement.X == 0 && movement.Y == 0 {
+		return Hit{}, false
+	}
+
+	// Grow the target by half the mover so the mover can be treated as a point
+	expandedMin := Vector2Subtract(target.Min(), Vector2Scale(moving.Size, 0.5))
+	expandedMax := Vector2Add(target.Max(), Vector2Scale(moving.Size, 0.5))
+
+	entryX, exitX, ok := sweepAxis(moving.CenterPosition.X, movement.X, expandedMin.X, expandedMax.X)
+	if !ok {
+		return Hit{}, false
+	}
+	entryY, exitY, ok := sweepAxis(moving.CenterPosition.Y, movement.Y, expandedMin.Y, expandedMax.Y)
+	if !ok {
+		return Hit{}, false
+	}
+
+	entry := Max(entryX, entryY)
+	exit := Min(exitX, exitY)
+	if entry > exit || exit <= 0 || entry > 1 {
+		return Hit{}, false
+	}
+
+	if entry <= 0 {
+		// Already touching, so the face is the one the mover is least far into
+		hit := Hit{Normal: nearestFace(moving.CenterPosition, expandedMin, expandedMax)}
+		if Vector2DotProduct(movement, hit.Normal) >= 0 {
+			return Hit{}, false
+		}
+		return hit, true
+	}
+
+	hit := Hit{Time: entry}
+	if entryX > entryY {
+		hit.Normal = Vector2{-sign(movement.X), 0}
+	} else {
+		hit.Normal = Vector2{0, -sign(movement.Y)}
+	}
+	return hit, true
+}
+
+// nearestFace returns the outward normal of the side of [min, max] closest to point.
+func nearestFace(point Vector2, min Vector2, max Vector2) Vector2 {
+	left, right := point.X-min.X, max.X-point.X
+	top, bottom := point.Y-min.Y, max.Y-point.Y
+	nearest := Min(Min(left, right), Min(top, bottom))
+	switch nearest {
+	case left:
+		return Vector2{-1, 0}
+	case right:
+		return Vector2{1, 0}
+	case top:
+		return Vector2{0, -1}
+	default:
+		return Vector2{0, 1}
+	}
+}
+
+// sweepAxis returns the fractions of movement at which position enters and
+// leaves [min, max] on one axis. ok is false when it never overlaps.
+func sweepAxis(position float32, movement float32, min float32, max float32) (entry float32, exit float32, ok bool) {
+	if movement == 0 {
+		if position < min || position > max {
+			return 0, 0, false
+		}
+		return float32(math.Inf(-1)), float32(math.Inf(1)), true
+	}
+	entry = (min - position) / movement
+	exit = (max - position) / movement
+	if entry > exit {
+		entry, exit = exit, entry
+	}
+	return entry, exit, true
+}
+
+func sign(value float32) float32 {
+	if value < 0 {
+		return -1
+	}
+	return 1
+}
+
+// Reflect bounces velocity off a surface with the given unit normal.
+func Reflect(velocity Vector2, normal Vector2) Vector2 {
+	return Vector2Subtract(velocity, Vector2Scale(normal, 2*Vector2DotProduct(velocity, normal)))
+}
+
+// MoveSwept moves the ball through deltaTime without passing through anything.
+// collide gets the movement left and returns the earliest hit along it, after
+// changing the ball's velocity however that hit should. The ball is moved up to
+// the hit and carries on with its new velocity for the rest of the time. If
+// collide leaves the ball heading into the face it hit, the ball slides along
+// it instead, so a hit at Time 0 can't be reported again by the same face.
+func (b *Ball) MoveSwept(deltaTime float32, collide func(movement Vector2) (Hit, bool)) {
+	b.StartTick()
+	remaining := deltaTime
+	for i := 0; i < MaxSweepHits && remaining > 0; i++ {
+		movement := Vector2Scale(b.Velocity, remaining)
+		hit, ok := collide(movement)
+		if !ok {
+			b.CenterPosition = Vector2Add(b.CenterPosition, movement)
+			return
+		}
+		b.CenterPosition = Vector2Add(b.CenterPosition, Vector2Scale(movement, hit.Time))
+		remaining -= remaining * hit.Time
+		if into := Vector2DotProduct(b.Velocity, hit.Normal); into < 0 {
+			b.Velocity = Vector2Subtract(b.Velocity, Vector2Scale(hit.Normal, into))
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package engine
+
+import "testing"
+
+func TestSweptAABB(t *testing.T) {
+	// A 10x10 mover against a 20x20 wall centred on the origin
+	target := Rectangle{CenterPosition: Vector2{0, 0}, Size: Vector2{20, 20}}
+	size := Vector2{10, 10}
+
+	tests := []struct {
+		name     string
+		position Vector2
+		movement Vector2
+		hit      bool
+		time     float32
+		normal   Vector2
+	}{
+		{"approach from the left", Vector2{-25, 0}, Vector2{20, 0}, true, 0.5, Vector2{-1, 0}},
+		{"approach from below", Vector2{3, 30}, Vector2{0, -30}, true, 0.5, Vector2{0, 1}},
+		{"falls short", Vector2{-25, 0}, Vector2{5, 0}, false, 0, Vector2{}},
+		{"passes by", Vector2{-25, 20}, Vector2{50, 0}, false, 0, Vector2{}},
+		{"touching and moving in", Vector2{-15, 0}, Vector2{5, 0}, true, 0, Vector2{-1, 0}},
+		{"overlapping and moving in", Vector2{-13, 2}, Vector2{5, 1}, true, 0, Vector2{-1, 0}},
+		{"touching and moving away", Vector2{0, -15}, Vector2{1, -5}, false, 0, Vector2{}},
+		{"overlapping and moving away", Vector2{13, 0}, Vector2{5, 0}, false, 0, Vector2{}},
+		{"sliding along the top", Vector2{-10, -15}, Vector2{20, 0}, false, 0, Vector2{}},
+		{"sliding along the side", Vector2{15, 5}, Vector2{0, -20}, false, 0, Vector2{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			moving := Rectangle{CenterPosition: test.position, Size: size}
+			hit, ok := SweptAABB(moving, test.movement, target)
+			if ok != test.hit {
+				t.Fatalf("hit = %v, want %v (%+v)", ok, test.hit, hit)
+			}
+			if ok && (hit.Time != test.time || hit.Normal != test.normal) {
+				t.Errorf("got %+v, want time %v normal %v", hit, test.time, test.normal)
+			}
+		})
+	}
+}
+
+func TestMoveSweptMakesProgress(t *testing.T) {
+	wall := Rectangle{CenterPosition: Vector2{0, -5}, Size: Vector2{100, 10}}
+	tests := []struct {
+		name     string
+		position Vector2
+		velocity Vector2
+		reflect  bool
+		want     Vector2
+	}{
+		{"bounces off the wall it touches", Vector2{0, 5}, Vector2{10, -100}, true, Vector2{10, 105}},
+		{"leaves the wall it touches", Vector2{0, 5}, Vector2{10, 100}, true, Vector2{10, 105}},
+		{"slides when the hit doesn't turn it", Vector2{0, 5}, Vector2{10, -100}, false, Vector2{10, 5}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ball := Ball{Mover: Mover{Rectangle: Rectangle{CenterPosition: test.position, Size: Vector2{10, 10}}}, Velocity: test.velocity}
+			hits := 0
+			ball.MoveSwept(1, func(movement Vector2) (Hit, bool) {
+				hit, ok := SweptAABB(ball.Rectangle, movement, wall)
+				if ok {
+					hits++
+					if test.reflect {
+						ball.Velocity = Reflect(ball.Velocity, hit.Normal)
+					}
+				}
+				return hit, ok
+			})
+			if ball.CenterPosition != test.want {
+				t.Errorf("position = %v, want %v", ball.CenterPosition, test.want)
+			}
+			if hits > 1 {
+				t.Errorf("hit the wall %d times, want at most once", hits)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+// Package engine holds the pieces shared by the games: the frame loop, the
+// Rectangle/Ball/Pad types and the text helpers. It doesn't use raylib, so the
+// games can be tested without a display; the window, keyboard and mouse are in
+// hackweek/engine/backend.
+package engine
+
+const (
+	ScreenWidth  = 800
+	ScreenHeight = 450
+	TargetFPS    = 60
+)
+
+// Game is the set of callbacks Platform.Run drives. Setup is called once after
+// the window is open, then Update is called at a fixed TickRate and Draw once per
+// frame with the interpolation alpha between the last two ticks. Nothing in
+// Setup, Update or Draw should need the window so a test can step a game with
+// a RecordingRenderer.
+type Game struct {
+	Title            string
+	TickRate         int // Defaults to DefaultTickRate
+	MaxStepsPerFrame int // Defaults to DefaultMaxStepsPerFrame
+	Setup            func()
+	Update           func(deltaTime float32)
+	Draw             func(renderer Renderer, alpha float32)
+}
+
+// Platform is what a game needs from the machine it runs on: the window, the
+// keyboard and the mouse. backend.Raylib is the real one.
+type Platform interface {
+	NewKeyboardInput(bindings map[Action]Key) Input
+	NewMouseInput(keys map[Action]Key, buttons map[Action]MouseButton) Pointer
+	Run(game Game)
+}
@@ -0,0 +1,153 @@
+package engine
+
+// Action is a named input the games react to, independent of what produces it.
+type Action int
+
+const (
+	MoveUp Action = iota
+	MoveDown
+	MoveLeft
+	MoveRight
+	Shoot
+	Pause
+	Confirm
+	PointerPrimary   // Clicking on what is under a Pointer
+	PointerSecondary // The other click, e.g. a right click
+	SwitchMode       // Going between two modes, like an editor and play-testing
+	Save
+	Load
+	ActionCount
+)
+
+// Input is a source of actions. Update samples the source once per tick and
+// must be called before IsDown/IsPressed are read for that tick.
+type Input interface {
+	Update()
+	IsDown(action Action) bool
+	IsPressed(action Action) bool
+}
+
+// Pointer is an Input that also points somewhere on screen, like a mouse.
+// Position is sampled by Update with the actions.
+type Pointer interface {
+	Input
+	Position() Vector2
+}
+
+// ActionState is the current and previous tick's down state of every action.
+// The Input implementations embed it so they only have to fill in Update.
+type ActionState struct {
+	current  [ActionCount]bool
+	previous [ActionCount]bool
+}
+
+func (s *ActionState) IsDown(action Action) bool {
+	return s.current[action]
+}
+
+// IsPressed reports whether the action went down on this tick.
+func (s *ActionState) IsPressed(action Action) bool {
+	return s.current[action] && !s.previous[action]
+}
+
+// Advance moves on a tick, with next as the down state of every action. Update calls it once it has sampled its source.
+func (s *ActionState) Advance(next [ActionCount]bool) {
+	s.previous = s.current
+	s.current = next
+}
+
+// ProgrammaticInput is driven from code, e.g. by a bot or a test. Set changes
+// and SetPosition changes take effect on the next Update.
+type ProgrammaticInput struct {
+	ActionState
+	next         [ActionCount]bool
+	position     Vector2
+	nextPosition Vector2
+}
+
+func (p *ProgrammaticInput) Set(action Action, isDown bool) {
+	p.next[action] = isDown
+}
+
+// SetPosition moves where the input points, for code standing in for a mouse.
+func (p *ProgrammaticInput) SetPosition(position Vector2) {
+	p.nextPosition = position
+}
+
+func (p *ProgrammaticInput) Update() {
+	p.position = p.nextPosition
+	p.Advance(p.next)
+}
+
+func (p *ProgrammaticInput) Position() Vector2 {
+	return p.position
+}
+
+// InputEvent is an action changing state on a given tick.
+type InputEvent struct {
+	Tick   int
+	Action Action
+	IsDown bool
+}
+
+// ScriptedInput plays back a list of events sorted by Tick, e.g. a recorded replay.
+// The first Update is tick 0.
+type ScriptedInput struct {
+	ActionState
+	Events    []InputEvent
+	tick      int
+	nextEvent int
+	next      [ActionCount]bool
+}
+
+func NewScriptedInput(events []InputEvent) *ScriptedInput {
+	return &ScriptedInput{Events: events}
+}
+
+func (s *ScriptedInput) Update() {
+	for s.nextEvent < len(s.Events) && s.Events[s.nextEvent].Tick <= s.tick {
+		event := s.Events[s.nextEvent]
+		s.next[event.Action] = event.IsDown
+		s.nextEvent++
+	}
+	s.Advance(s.next)
+	s.tick++
+}
+
+// IsFinished reports whether every event has been played.
+func (s *ScriptedInput) IsFinished() bool {
+	return s.nextEvent >= len(s.Events)
+}
+
+// RecordingInput passes another Input through unchanged and keeps every state
+// change as an InputEvent, ready to be replayed with NewScriptedInput.
+type RecordingInput struct {
+	Source Input
+	Events []InputEvent
+	tick   int
+	isDown [ActionCount]bool
+}
+
+func NewRecordingInput(source Input) *RecordingInput {
+	return &RecordingInput{Source: source}
+}
+
+func (r *RecordingInput) Update() {
+	r.Source.Update()
+	for action := Action(0); action < ActionCount; action++ {
+		isDown := r.Source.IsDown(action)
+		if isDown != r.isDown[action] {
+			r.isDown[action] = isDown
+			r.Events = append(r.Events, InputEvent{Tick: r.tick, Action: action, IsDown: isDown})
+		}
+	}
+	r.tick++
+}
+
+func (r *RecordingInput) IsDown(action Action) bool {
+	return r.Source.IsDown(action)
+}
+
+func (r *RecordingInput) IsPressed(action Action) bool {
+	return r.Source.IsPressed(action)
+}
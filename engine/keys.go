@@ -0,0 +1,67 @@
+package engine
+
+// Key is a key on the keyboard, numbered the way raylib numbers them so the backend can hand it straight over.
+type Key int32
+
+const (
+	KeySpace     Key = 32
+	KeyEscape    Key = 256
+	KeyEnter     Key = 257
+	KeyTab       Key = 258
+	KeyBackspace Key = 259
+	KeyRight     Key = 262
+	KeyLeft      Key = 263
+	KeyDown      Key = 264
+	KeyUp        Key = 265
+)
+
+const (
+	KeyZero Key = 48 + iota
+	KeyOne
+	KeyTwo
+	KeyThree
+	KeyFour
+	KeyFive
+	KeySix
+	KeySeven
+	KeyEight
+	KeyNine
+)
+
+const (
+	KeyA Key = 65 + iota
+	KeyB
+	KeyC
+	KeyD
+	KeyE
+	KeyF
+	KeyG
+	KeyH
+	KeyI
+	KeyJ
+	KeyK
+	KeyL
+	KeyM
+	KeyN
+	KeyO
+	KeyP
+	KeyQ
+	KeyR
+	KeyS
+	KeyT
+	KeyU
+	KeyV
+	KeyW
+	KeyX
+	KeyY
+	KeyZ
+)
+
+// MouseButton is a button on the mouse, numbered the way raylib numbers them.
+type MouseButton int32
+
+const (
+	MouseLeftButton MouseButton = iota
+	MouseRightButton
+	MouseMiddleButton
+)
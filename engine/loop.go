@@ -0,0 +1,50 @@
+package engine
+
+const (
+	DefaultTickRate         = 120
+	DefaultMaxStepsPerFrame = 8
+)
+
+// FixedStep turns variable frame times into a whole number of fixed length
+// ticks so the simulation gives the same result for the same inputs whatever
+// the render frame rate is.
+type FixedStep struct {
+	TickRate         int // Ticks per second
+	MaxStepsPerFrame int // Catch-up limit so a long hitch can't stall the game in a spiral of updates
+	accumulator      float32
+}
+
+func NewFixedStep(tickRate int, maxStepsPerFrame int) *FixedStep {
+	if tickRate <= 0 {
+		tickRate = DefaultTickRate
+	}
+	if maxStepsPerFrame <= 0 {
+		maxStepsPerFrame = DefaultMaxStepsPerFrame
+	}
+	return &FixedStep{TickRate: tickRate, MaxStepsPerFrame: maxStepsPerFrame}
+}
+
+func (f *FixedStep) TickDuration() float32 {
+	return 1 / float32(f.TickRate)
+}
+
+// Advance adds frameTime to the accumulator and calls update once per whole
+// tick it holds. It returns the interpolation alpha, how far (0 to 1) the
+// leftover time is into the next tick, for drawing between the last two states.
+func (f *FixedStep) Advance(frameTime float32, update func(deltaTime float32)) float32 {
+	tickDuration := f.TickDuration()
+	f.accumulator += frameTime
+
+	steps := 0
+	for f.accumulator >= tickDuration {
+		if steps >= f.MaxStepsPerFrame {
+			// Drop the backlog we could not catch up on, keep the partial tick
+			f.accumulator -= tickDuration * float32(int(f.accumulator/tickDuration))
+			break
+		}
+		update(tickDuration)
+		f.accumulator -= tickDuration
+		steps++
+	}
+	return f.accumulator / tickDuration
+}
@@ -0,0 +1,108 @@
+package engine
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestFixedStepIgnoresFrameRate(t *testing.T) {
+	const tickRate = 120
+	// Half a tick past a whole number of them, so float rounding in the accumulator can't tip the count either way
+	total := 2 + 0.5/float32(tickRate)
+
+	type result struct {
+		ticks    int
+		position float32
+		alpha    float32
+	}
+	run := func(frames []float32) result {
+		f := NewFixedStep(tickRate, math.MaxInt32)
+		r := result{}
+		for _, frameTime := range frames {
+			r.alpha = f.Advance(frameTime, func(deltaTime float32) {
+				r.ticks++
+				r.position += 90 * deltaTime
+			})
+		}
+		return r
+	}
+	even := func(count int) []float32 {
+		frames := make([]float32, count)
+		for n := range frames {
+			frames[n] = total / float32(count)
+		}
+		return frames
+	}
+	uneven := func() []float32 {
+		rng := rand.New(rand.NewSource(1))
+		var frames []float32
+		left := total
+		for left > 0.05 {
+			frameTime := 0.001 + rng.Float32()*0.04
+			frames = append(frames, frameTime)
+			left -= frameTime
+		}
+		return append(frames, left)
+	}
+
+	want := run([]float32{total})
+	if want.ticks != 2*tickRate {
+		t.Fatalf("ran %d ticks in one frame, want %d", want.ticks, 2*tickRate)
+	}
+	tests := []struct {
+		name   string
+		frames []float32
+	}{
+		{"30 fps", even(60)},
+		{"60 fps", even(120)},
+		{"144 fps", even(288)},
+		{"faster than the tick rate", even(1000)},
+		{"uneven frames", uneven()},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := run(test.frames)
+			if got.ticks != want.ticks || got.position != want.position {
+				t.Errorf("ran %d ticks to %v, want %d to %v", got.ticks, got.position, want.ticks, want.position)
+			}
+			if math.Abs(float64(got.alpha-want.alpha)) > 0.01 {
+				t.Errorf("alpha = %v, want %v", got.alpha, want.alpha)
+			}
+		})
+	}
+}
+
+func TestFixedStepDropsWhatItCantCatchUpOn(t *testing.T) {
+	f := NewFixedStep(100, 4)
+	ticks := 0
+	alpha := f.Advance(1.005, func(deltaTime float32) { ticks++ })
+	if ticks != 4 {
+		t.Fatalf("ran %d ticks after a long hitch, want 4", ticks)
+	}
+	if math.Abs(float64(alpha-0.5)) > 0.01 {
+		t.Errorf("alpha = %v, want the partial tick 0.5 kept", alpha)
+	}
+
+	// The dropped backlog doesn't come back on the next frame
+	ticks = 0
+	f.Advance(0.01, func(deltaTime float32) { ticks++ })
+	if ticks != 1 {
+		t.Errorf("ran %d ticks on the frame after, want 1", ticks)
+	}
+}
+
+func TestFixedStepAlphaStaysInATick(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	f := NewFixedStep(DefaultTickRate, DefaultMaxStepsPerFrame)
+	for n := 0; n < 10000; n++ {
+		frameTime := rng.Float32() * 0.1
+		if n%100 == 0 {
+			frameTime = 0.5 // A hitch past the catch-up limit now and then
+		}
+		alpha := f.Advance(frameTime, func(deltaTime float32) {})
+		if alpha < 0 || alpha >= 1 {
+			t.Fatalf("frame %d of %v: alpha = %v, want 0 to under 1", n, frameTime, alpha)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package engine
+
+import "fmt"
+
+// ParseError is a problem in a data file, like a level or a wave script,
+// pointing at where it was found. It prints as file:line:column: message.
+type ParseError struct {
+	File    string
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%s:%d:%d: %s", e.File, e.Line, e.Column, e.Message)
+}
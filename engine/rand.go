@@ -0,0 +1,23 @@
+package engine
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// SeedFlag registers the -seed flag the games use to reproduce a run.
+func SeedFlag() *int64 {
+	return flag.Int64("seed", 0, "random seed to reproduce a run, 0 picks one from the clock")
+}
+
+// NewRand returns a generator for seed, picking one from the clock when seed
+// is 0. The seed is printed so it can go in a bug report.
+func NewRand(seed int64) *rand.Rand {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	fmt.Println("seed:", seed)
+	return rand.New(rand.NewSource(seed))
+}
@@ -0,0 +1,98 @@
+package engine
+
+// Renderer is everything a game needs to draw a frame. Games draw through it
+// instead of calling raylib directly so they can run without a window; the
+// raylib one is in hackweek/engine/backend.
+type Renderer interface {
+	Clear(color Color)
+	DrawRectangle(posX int32, posY int32, width int32, height int32, color Color)
+	DrawLine(from Vector2, to Vector2, thickness float32, color Color)
+	DrawText(text string, posX int32, posY int32, fontSize int32, color Color)
+	MeasureText(text string, fontSize int32) int32
+}
+
+type DrawCallKind int
+
+const (
+	ClearCall DrawCallKind = iota
+	RectangleCall
+	LineCall
+	TextCall
+)
+
+// DrawCall is one recorded Renderer call. Only the fields relevant to Kind are set:
+// Position and Size for rectangles, Position and To for lines, Position and Text for text.
+type DrawCall struct {
+	Kind      DrawCallKind
+	Position  Vector2
+	Size      Vector2
+	To        Vector2
+	Thickness float32
+	Text      string
+	FontSize  int32
+	Color     Color
+}
+
+// RecordingRenderer keeps every draw call in memory instead of drawing, so a
+// frame can be inspected from plain go test without a display.
+type RecordingRenderer struct {
+	Calls []DrawCall
+}
+
+func (r *RecordingRenderer) Clear(color Color) {
+	r.Calls = append(r.Calls, DrawCall{Kind: ClearCall, Color: color})
+}
+
+func (r *RecordingRenderer) DrawRectangle(posX int32, posY int32, width int32, height int32, color Color) {
+	r.Calls = append(r.Calls, DrawCall{
+		Kind:     RectangleCall,
+		Position: Vector2{float32(posX), float32(posY)},
+		Size:     Vector2{float32(width), float32(height)},
+		Color:    color,
+	})
+}
+
+func (r *RecordingRenderer) DrawLine(from Vector2, to Vector2, thickness float32, color Color) {
+	r.Calls = append(r.Calls, DrawCall{Kind: LineCall, Position: from, To: to, Thickness: thickness, Color: color})
+}
+
+func (r *RecordingRenderer) DrawText(text string, posX int32, posY int32, fontSize int32, color Color) {
+	r.Calls = append(r.Calls, DrawCall{
+		Kind:     TextCall,
+		Position: Vector2{float32(posX), float32(posY)},
+		Text:     text,
+		FontSize: fontSize,
+		Color:    color,
+	})
+}
+
+// MeasureText approximates the default raylib font, where a glyph is about half as wide as it is tall.
+func (r *RecordingRenderer) MeasureText(text string, fontSize int32) int32 {
+	return int32(len(text)) * fontSize / 2
+}
+
+// Reset drops the recorded calls so the renderer can capture the next frame.
+func (r *RecordingRenderer) Reset() {
+	r.Calls = r.Calls[:0]
+}
+
+// CallsOfKind returns the recorded calls of the given kind in the order they were made.
+func (r *RecordingRenderer) CallsOfKind(kind DrawCallKind) []DrawCall {
+	var calls []DrawCall
+	for _, call := range r.Calls {
+		if call.Kind == kind {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// HasText reports whether any recorded text call drew exactly text.
+func (r *RecordingRenderer) HasText(text string) bool {
+	for _, call := range r.Calls {
+		if call.Kind == TextCall && call.Text == text {
+			return true
+		}
+	}
+	return false
+}
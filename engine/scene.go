@@ -0,0 +1,159 @@
+package engine
+
+// Scene is one state of a game, e.g. its title screen or the game itself.
+// Either callback can be nil.
+type Scene struct {
+	Name      string
+	Update    func(deltaTime float32)
+	Draw      func(renderer Renderer, alpha float32)
+	IsOverlay bool // Drawn on top of the scene below it instead of replacing it, like a pause menu
+}
+
+// SceneStack runs the scene on top. Push enters a scene that returns to the
+// current one with Pop, Switch moves to another scene for good. Its Update and
+// Draw are meant to be handed to Platform.Run as the game's.
+type SceneStack struct {
+	scenes []*Scene
+}
+
+func (s *SceneStack) Push(scene *Scene) {
+	s.scenes = append(s.scenes, scene)
+}
+
+// Pop leaves the top scene, going back to the one under it.
+func (s *SceneStack) Pop() {
+	if len(s.scenes) > 0 {
+		s.scenes = s.scenes[:len(s.scenes)-1]
+	}
+}
+
+// Switch replaces the whole stack with scene.
+func (s *SceneStack) Switch(scene *Scene) {
+	s.scenes = append(s.scenes[:0], scene)
+}
+
+// Top returns the running scene, or nil if the stack is empty.
+func (s *SceneStack) Top() *Scene {
+	if len(s.scenes) == 0 {
+		return nil
+	}
+	return s.scenes[len(s.scenes)-1]
+}
+
+// Update only updates the top scene, so anything under an overlay stays frozen.
+func (s *SceneStack) Update(deltaTime float32) {
+	if top := s.Top(); top != nil && top.Update != nil {
+		top.Update(deltaTime)
+	}
+}
+
+// Draw draws the top scene and, under an overlay, every scene down to the first one that isn't.
+// Only the top scene is updated, so the ones under it are drawn where they stopped, with an alpha of 1.
+func (s *SceneStack) Draw(renderer Renderer, alpha float32) {
+	top := len(s.scenes) - 1
+	bottom := top
+	for bottom > 0 && s.scenes[bottom].IsOverlay {
+		bottom--
+	}
+	for n := bottom; n >= 0 && n <= top; n++ {
+		if s.scenes[n].Draw == nil {
+			continue
+		}
+		if n == top {
+			s.scenes[n].Draw(renderer, alpha)
+		} else {
+			s.scenes[n].Draw(renderer, 1)
+		}
+	}
+}
+
+// Press holds action down on input for one tick and lets it go on the next, for driving the scenes from tests.
+func (s *SceneStack) Press(input *ProgrammaticInput, action Action, deltaTime float32) {
+	input.Set(action, true)
+	s.Update(deltaTime)
+	input.Set(action, false)
+	s.Update(deltaTime)
+}
+
+// DrawFrame draws the scenes into a new RecordingRenderer, for checking what they draw from tests.
+func (s *SceneStack) DrawFrame(alpha float32) *RecordingRenderer {
+	renderer := &RecordingRenderer{}
+	s.Draw(renderer, alpha)
+	return renderer
+}
+
+// DrawBanner dims the screen with dim and writes a big title with a smaller prompt under it, for menus and overlays.
+func DrawBanner(renderer Renderer, title string, prompt string, dim Color, color Color) {
+	renderer.DrawRectangle(0, 0, ScreenWidth, ScreenHeight, dim)
+	DrawText(renderer, title, Center, ScreenWidth/2, ScreenHeight/2-40, 50, color)
+	DrawText(renderer, prompt, Center, ScreenWidth/2, ScreenHeight/2+20, 20, color)
+}
+
+// Menu builds the title, pause and game over scenes every game has. Confirm
+// (Enter) starts and restarts, Pause (P) or Confirm carries on from a pause.
+type Menu struct {
+	Stack      *SceneStack
+	Input      Input // Updated and read by the menu scenes while they are on top
+	Title      string
+	Controls   string // Shown small at the bottom of the title screen
+	Background Color  // Behind the title screen
+	Dim        Color  // Over the game under the pause and game over screens
+	Text       Color
+	Hint       Color // Color of Controls
+}
+
+// TitleScene shows the game's title until Confirm, then runs onStart and switches to playing.
+func (m *Menu) TitleScene(playing *Scene, onStart func()) *Scene {
+	return &Scene{
+		Name: "title",
+		Update: func(deltaTime float32) {
+			m.Input.Update()
+			if m.Input.IsPressed(Confirm) {
+				onStart()
+				m.Stack.Switch(playing)
+			}
+		},
+		Draw: func(renderer Renderer, alpha float32) {
+			renderer.Clear(m.Background)
+			DrawText(renderer, m.Title, Center, ScreenWidth/2, ScreenHeight/2-40, 50, m.Text)
+			DrawText(renderer, "Press Enter to start", Center, ScreenWidth/2, ScreenHeight/2+20, 20, m.Text)
+			DrawText(renderer, m.Controls, Center, ScreenWidth/2, ScreenHeight-40, 10, m.Hint)
+		},
+	}
+}
+
+// PausedScene is an overlay that freezes the scene under it until Pause or Confirm.
+func (m *Menu) PausedScene() *Scene {
+	return &Scene{
+		Name: "paused",
+		Update: func(deltaTime float32) {
+			m.Input.Update()
+			if m.Input.IsPressed(Pause) || m.Input.IsPressed(Confirm) {
+				m.Stack.Pop()
+			}
+		},
+		Draw: func(renderer Renderer, alpha float32) {
+			DrawBanner(renderer, "Paused", "Press P to carry on", m.Dim, m.Text)
+		},
+		IsOverlay: true,
+	}
+}
+
+// GameOverScene is an overlay showing banner() over the finished game until
+// Confirm, which runs onRestart and goes back to playing.
+func (m *Menu) GameOverScene(banner func() string, prompt string, onRestart func()) *Scene {
+	return &Scene{
+		Name: "game over",
+		Update: func(deltaTime float32) {
+			m.Input.Update()
+			if m.Input.IsPressed(Confirm) {
+				onRestart()
+				m.Stack.Pop()
+			}
+		},
+		Draw: func(renderer Renderer, alpha float32) {
+			DrawBanner(renderer, banner(), prompt, m.Dim, m.Text)
+		},
+		IsOverlay: true,
+	}
+}
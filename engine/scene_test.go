@@ -0,0 +1,26 @@
+package engine
+
+import "testing"
+
+func TestSceneStackDrawsFrozenScenesWithoutInterpolating(t *testing.T) {
+	var drawn []float32
+	draw := func(renderer Renderer, alpha float32) {
+		drawn = append(drawn, alpha)
+	}
+	stack := &SceneStack{}
+	stack.Push(&Scene{Name: "title", Draw: draw})
+	stack.Push(&Scene{Name: "playing", Draw: draw})
+	stack.Push(&Scene{Name: "paused", Draw: draw, IsOverlay: true})
+	stack.Push(&Scene{Name: "help", Draw: draw, IsOverlay: true})
+
+	stack.Draw(&RecordingRenderer{}, 0.25)
+	want := []float32{1, 1, 0.25} // The title is covered, and only the help overlay is still running
+	if len(drawn) != len(want) {
+		t.Fatalf("drew %d scenes, want %d", len(drawn), len(want))
+	}
+	for n := range want {
+		if drawn[n] != want[n] {
+			t.Errorf("scene %d drawn with alpha %v, want %v", n, drawn[n], want[n])
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package engine
+
+type Rectangle struct {
+	CenterPosition Vector2
+	Size           Vector2
+}
+
+// Mover is a rectangle that moves from tick to tick. It remembers where it was
+// on the last tick so it can be drawn in between with Interpolated.
+type Mover struct {
+	Rectangle
+	PreviousPosition Vector2 // CenterPosition before the last StartTick, for drawing between ticks
+}
+
+type Ball struct {
+	Mover
+	Velocity Vector2
+}
+
+type Pad struct {
+	Mover
+	Input    Input
+	Score    int
+	Velocity Vector2
+}
+
+// Min returns the top left corner of the rectangle.
+func (r Rectangle) Min() Vector2 {
+	return Vector2{r.CenterPosition.X - (r.Size.X / 2), r.CenterPosition.Y - (r.Size.Y / 2)}
+}
+
+// Max returns the bottom right corner of the rectangle.
+func (r Rectangle) Max() Vector2 {
+	return Vector2{r.CenterPosition.X + (r.Size.X / 2), r.CenterPosition.Y + (r.Size.Y / 2)}
+}
+
+// Overlaps reports whether the two rectangles touch or intersect.
+func (r Rectangle) Overlaps(other Rectangle) bool {
+	rMin, otherMin := r.Min(), other.Min()
+	hasCollisionX := rMin.X+r.Size.X >= otherMin.X && otherMin.X+other.Size.X >= rMin.X
+	hasCollisionY := rMin.Y+r.Size.Y >= otherMin.Y && otherMin.Y+other.Size.Y >= rMin.Y
+	return hasCollisionX && hasCollisionY
+}
+
+// MoveX moves the rectangle horizontally and clamps it between the left edge and width.
+func (r *Rectangle) MoveX(amount float32, width int) {
+	r.CenterPosition.X += amount
+	// Clamp on right edge
+	if r.CenterPosition.X+(r.Size.X/2) > float32(width) {
+		r.CenterPosition.X = float32(width) - (r.Size.X / 2)
+	}
+	// Clamp on left edge
+	if r.CenterPosition.X-(r.Size.X/2) < 0 {
+		r.CenterPosition.X = (r.Size.X / 2)
+	}
+}
+
+// MoveY moves the rectangle vertically and clamps it between the top edge and height.
+func (r *Rectangle) MoveY(amount float32, height int) {
+	r.CenterPosition.Y += amount
+	// Clamp on bottom edge
+	if r.CenterPosition.Y+(r.Size.Y/2) > float32(height) {
+		r.CenterPosition.Y = float32(height) - (r.Size.Y / 2)
+	}
+	// Clamp on top edge
+	if r.CenterPosition.Y-(r.Size.Y/2) < 0 {
+		r.CenterPosition.Y = (r.Size.Y / 2)
+	}
+}
+
+// StartTick remembers where the mover is before this tick moves it.
+func (m *Mover) StartTick() {
+	m.PreviousPosition = m.CenterPosition
+}
+
+// Teleport puts the mover at position without it being drawn sliding there.
+func (m *Mover) Teleport(position Vector2) {
+	m.CenterPosition = position
+	m.PreviousPosition = position
+}
+
+// Interpolated returns the mover's rectangle alpha of the way from its previous to its current position.
+func (m Mover) Interpolated(alpha float32) Rectangle {
+	r := m.Rectangle
+	r.CenterPosition = Vector2Lerp(m.PreviousPosition, m.CenterPosition, alpha)
+	return r
+}
+
+func DrawRectangle(renderer Renderer, r Rectangle, color Color) {
+	min := r.Min()
+	renderer.DrawRectangle(int32(min.X), int32(min.Y), int32(r.Size.X), int32(r.Size.Y), color)
+}
+
+func Max(a float32, b float32) float32 { // Yes Math really doesn't have a max for float32.
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func Min(a float32, b float32) float32 {
+	if a < b {
+		return a
+	}
+	return b
+}
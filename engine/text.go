@@ -0,0 +1,21 @@
+package engine
+
+type TextAlignment int64
+
+const (
+	Left TextAlignment = iota
+	Center
+	Right
+)
+
+func DrawText(renderer Renderer, text string, alignment TextAlignment, posX int32, posY int32, fontSize int32, fontColor Color) {
+	if alignment == Left {
+		renderer.DrawText(text, posX, posY, fontSize, fontColor)
+	} else if alignment == Center {
+		textSize := renderer.MeasureText(text, fontSize)
+		renderer.DrawText(text, posX-(textSize/2), posY, fontSize, fontColor)
+	} else if alignment == Right {
+		textSize := renderer.MeasureText(text, fontSize)
+		renderer.DrawText(text, posX-textSize, posY, fontSize, fontColor)
+	}
+}
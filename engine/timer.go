@@ -0,0 +1,18 @@
+package engine
+
+// HasHitInterval counts timeRemaining down and reports true each time it runs
+// out, resetting it to resetTime.
+func HasHitInterval(timeRemaining *float32, resetTime float32, deltaTime float32) bool {
+	*timeRemaining -= deltaTime
+	if *timeRemaining <= 0 {
+		*timeRemaining = resetTime
+		return true
+	}
+	return false
+}
+
+// HasHitTime counts timeRemaining down and reports whether it has run out.
+func HasHitTime(timeRemaining *float32, deltaTime float32) bool {
+	*timeRemaining = *timeRemaining - deltaTime
+	return *timeRemaining <= 0
+}
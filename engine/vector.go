@@ -0,0 +1,47 @@
+package engine
+
+import "math"
+
+const Deg2rad = math.Pi / 180
+
+// Vector2 is a position, size or velocity in pixels. It has the same layout as
+// raylib's so the backend can convert it for free.
+type Vector2 struct {
+	X float32
+	Y float32
+}
+
+func Vector2Add(v1 Vector2, v2 Vector2) Vector2 {
+	return Vector2{v1.X + v2.X, v1.Y + v2.Y}
+}
+
+// Vector2Subtract returns v1 - v2.
+func Vector2Subtract(v1 Vector2, v2 Vector2) Vector2 {
+	return Vector2{v1.X - v2.X, v1.Y - v2.Y}
+}
+
+func Vector2Scale(v Vector2, scale float32) Vector2 {
+	return Vector2{v.X * scale, v.Y * scale}
+}
+
+func Vector2Length(v Vector2) float32 {
+	return float32(math.Sqrt(float64(v.X*v.X + v.Y*v.Y)))
+}
+
+func Vector2DotProduct(v1 Vector2, v2 Vector2) float32 {
+	return v1.X*v2.X + v1.Y*v2.Y
+}
+
+func Vector2Distance(v1 Vector2, v2 Vector2) float32 {
+	return Vector2Length(Vector2Subtract(v1, v2))
+}
+
+// Vector2Normalize returns v scaled to a length of 1. A zero vector has no direction and comes back as NaNs.
+func Vector2Normalize(v Vector2) Vector2 {
+	return Vector2Scale(v, 1/Vector2Length(v))
+}
+
+// Vector2Lerp returns the point amount (0 to 1) of the way from v1 to v2.
+func Vector2Lerp(v1 Vector2, v2 Vector2, amount float32) Vector2 {
+	return Vector2{v1.X + amount*(v2.X-v1.X), v1.Y + amount*(v2.Y-v1.Y)}
+}
@@ -2,4 +2,4 @@ module hackweek
 
 go 1.19
 
-require github.com/gen2brain/raylib-go/raylib v0.0.0-20221204123137-d6b1dea578e9 // indirect
+require github.com/gen2brain/raylib-go/raylib v0.0.0-20221204123137-d6b1dea578e9
@@ -0,0 +1,91 @@
+package pong
+
+import (
+	"hackweek/engine"
+	"math"
+	"math/rand"
+)
+
+// Difficulty tunes how well a Computer plays.
+type Difficulty struct {
+	ReactionTime    float32 // Seconds between looking at the ball again
+	PredictionError float32 // Most pixels the predicted intercept can be off by
+	SpeedScale      float32 // Fraction of the pad's speed it is allowed to use
+}
+
+var Difficulties = map[string]Difficulty{
+	"easy":   {ReactionTime: 0.5, PredictionError: 40, SpeedScale: 0.6},
+	"medium": {ReactionTime: 0.25, PredictionError: 20, SpeedScale: 0.8},
+	"hard":   {ReactionTime: 0.1, PredictionError: 5, SpeedScale: 1},
+}
+
+// Computer plays a Pad by pressing MoveUp/MoveDown like a human would, so it
+// goes through the same Update code. Assign it as the pad's Input.
+type Computer struct {
+	engine.ProgrammaticInput
+	Difficulty    Difficulty
+	pad           *engine.Pad
+	ball          *engine.Ball
+	rng           *rand.Rand
+	targetY       float32
+	aimError      float32
+	wasIncoming   bool
+	reactionTimer float32
+	moveBudget    float32
+}
+
+func NewComputer(pad *engine.Pad, ball *engine.Ball, difficulty Difficulty, rng *rand.Rand) *Computer {
+	return &Computer{Difficulty: difficulty, pad: pad, ball: ball, rng: rng, targetY: engine.ScreenHeight / 2}
+}
+
+func (c *Computer) Update() {
+	if engine.HasHitInterval(&c.reactionTimer, c.Difficulty.ReactionTime, TickDuration) {
+		interceptY, isIncoming := PredictBallY(*c.ball, c.pad.CenterPosition.X, engine.ScreenHeight)
+		if isIncoming && !c.wasIncoming {
+			// Pick one misjudgement per approach so it can't average out over the rally
+			c.aimError = (c.rng.Float32()*2 - 1) * c.Difficulty.PredictionError
+		}
+		c.wasIncoming = isIncoming
+		if isIncoming {
+			c.targetY = interceptY + c.aimError
+		} else {
+			c.targetY = engine.ScreenHeight / 2
+		}
+	}
+
+	// Only move on SpeedScale of the ticks so the pad's average speed is capped
+	canMove := false
+	c.moveBudget += c.Difficulty.SpeedScale
+	if c.moveBudget >= 1 {
+		c.moveBudget -= 1
+		canMove = true
+	}
+	deadZone := c.pad.Size.Y / 4
+	offsetY := c.targetY - c.pad.CenterPosition.Y
+	c.Set(engine.MoveDown, canMove && offsetY > deadZone)
+	c.Set(engine.MoveUp, canMove && offsetY < -deadZone)
+	c.ProgrammaticInput.Update()
+}
+
+// PredictBallY returns the height the ball will be at when it reaches x,
+// folding in bounces off the top and bottom edges. isIncoming is false when
+// the ball is moving away from x.
+func PredictBallY(ball engine.Ball, x float32, height float32) (y float32, isIncoming bool) {
+	distanceX := x - ball.CenterPosition.X
+	if ball.Velocity.X == 0 || distanceX*ball.Velocity.X < 0 {
+		return ball.CenterPosition.Y, false
+	}
+	timeToReach := distanceX / ball.Velocity.X
+	y = ball.CenterPosition.Y + ball.Velocity.Y*timeToReach
+
+	// Bounces mirror the path, so fold y back into the court every 2 * height
+	period := 2 * height
+	y = float32(math.Mod(float64(y), float64(period)))
+	if y < 0 {
+		y += period
+	}
+	if y > height {
+		y = period - y
+	}
+	return y, true
+}
@@ -0,0 +1,51 @@
+package pong
+
+import (
+	"hackweek/engine"
+	"testing"
+)
+
+func TestPredictBallY(t *testing.T) {
+	// A 100 high court, with the ball a second away from x = 100 when it's heading right
+	tests := []struct {
+		name       string
+		velocity   engine.Vector2
+		y          float32
+		isIncoming bool
+	}{
+		{"straight across", engine.Vector2{100, 0}, 50, true},
+		{"no bounce", engine.Vector2{100, 30}, 80, true},
+		{"off the bottom", engine.Vector2{100, 80}, 70, true},
+		{"off the top", engine.Vector2{100, -80}, 30, true},
+		{"off the bottom then the top", engine.Vector2{100, 180}, 30, true},
+		{"off the top then the bottom", engine.Vector2{100, -190}, 60, true},
+		{"heading away", engine.Vector2{-100, 30}, 50, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ball := engine.Ball{Velocity: test.velocity}
+			ball.CenterPosition = engine.Vector2{0, 50}
+			y, isIncoming := PredictBallY(ball, 100, 100)
+			if y != test.y || isIncoming != test.isIncoming {
+				t.Errorf("PredictBallY = %v, %v, want %v, %v", y, isIncoming, test.y, test.isIncoming)
+			}
+		})
+	}
+}
+
+func TestComputersFinishAMatch(t *testing.T) {
+	defer func(saved MatchRules) { rules = saved }(rules)
+	rules.WinScore, rules.WinByTwo = 3, true
+	computerRng := engine.NewRand(3)
+	left := NewComputer(&player1, &ball, Difficulties["easy"], computerRng)
+	right := NewComputer(&player2, &ball, Difficulties["hard"], computerRng)
+	menu := startTestMatch(3, left, right)
+	scenes.Press(menu, engine.Confirm, TickDuration)
+
+	for tick := 0; tick < 10*60*TickRate && scenes.Top() == playingScene; tick++ {
+		scenes.Update(TickDuration)
+	}
+	if scenes.Top() != matchOverScene || winner == nil {
+		t.Fatalf("match still going after 10 minutes at %d to %d", player1.Score, player2.Score)
+	}
+}
@@ -0,0 +1,10 @@
+package main
+
+import (
+	"hackweek/engine/backend"
+	"hackweek/pong"
+)
+
+func main() {
+	pong.Main(backend.Raylib{})
+}
@@ -0,0 +1,76 @@
+package pong
+
+import (
+	"hackweek/engine"
+	"math"
+)
+
+// MatchRules decides when a match is over and how the ball is served.
+type MatchRules struct {
+	WinScore      int     // Points needed to win
+	WinByTwo      bool    // Keep playing past WinScore until someone leads by two
+	ServeDelay    float32 // Seconds the ball waits in the middle before a serve
+	ServeSpeed    float32
+	MaxServeAngle float32 // Degrees either side of straight across
+}
+
+var rules = MatchRules{
+	WinScore:      11,
+	WinByTwo:      true,
+	ServeDelay:    1.0,
+	ServeSpeed:    55,
+	MaxServeAngle: 30,
+}
+
+var winner *engine.Pad
+var serveTarget *engine.Pad
+var m_TimerServe float32
+
+func StartMatch() {
+	winner = nil
+	for _, player := range players {
+		player.Score = 0
+	}
+	StartServe(players[rng.Intn(len(players))])
+}
+
+// StartServe parks the ball in the middle until the serve delay is up, then Serve launches it toward target.
+func StartServe(target *engine.Pad) {
+	serveTarget = target
+	m_TimerServe = rules.ServeDelay
+	ball.Teleport(InitialBallPosition)
+	ball.Velocity = engine.Vector2{}
+}
+
+func Serve() {
+	angle := (rng.Float32()*2 - 1) * rules.MaxServeAngle * engine.Deg2rad
+	directionX := float32(1)
+	if serveTarget.CenterPosition.X < ball.CenterPosition.X {
+		directionX = -1
+	}
+	ball.Velocity = engine.Vector2{
+		directionX * rules.ServeSpeed * float32(math.Cos(float64(angle))),
+		rules.ServeSpeed * float32(math.Sin(float64(angle))),
+	}
+	serveTarget = nil
+}
+
+// ScorePoint gives scorer a point, then either ends the match or serves to the player who lost the point.
+func ScorePoint(scorer *engine.Pad, loser *engine.Pad) {
+	scorer.Score += 1
+	if HasWonMatch(scorer, loser) {
+		winner = scorer
+		ball.Teleport(InitialBallPosition)
+		ball.Velocity = engine.Vector2{}
+		scenes.Push(matchOverScene)
+		return
+	}
+	StartServe(loser)
+}
+
+func HasWonMatch(player *engine.Pad, opponent *engine.Pad) bool {
+	if player.Score < rules.WinScore {
+		return false
+	}
+	return !rules.WinByTwo || player.Score-opponent.Score >= 2
+}
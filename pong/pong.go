@@ -1,174 +1,229 @@
-package main
+// Package pong is the game of pong, against another player or the computer.
+// The program that opens a window for it is cmd/pong.
+package pong
+
+import (
+	"flag"
+	"fmt"
+	"hackweek/engine"
+	"math"
+	"math/rand"
+	"os"
+	"strconv"
+)
 
-import raylib "github.com/gen2brain/raylib-go/raylib"
-import "strconv"
+var ball engine.Ball
+var player1 engine.Pad
+var player2 engine.Pad
+var players []*engine.Pad = []*engine.Pad{&player1, &player2}
+var playerSpeedsY [2]float32 // How fast each pad moved on the last tick, for spin
 
-type TextAlignment int64
+var matchInput engine.Input
+var rng *rand.Rand
 
-const (
-	Left TextAlignment = iota
-	Center
-	Right
-)
+var InitialBallPosition engine.Vector2
 
-type Rectangle struct {
-	centerPosition raylib.Vector2
-	size           raylib.Vector2
+// BallTuning controls how the ball comes off a pad.
+type BallTuning struct {
+	MaxBounceAngle float32 // Degrees off straight across when the ball hits the very edge of a pad
+	SpinAngle      float32 // Degrees added when the pad is moving at full speed, 0 turns spin off
+	SpeedUpPerHit  float32 // Multiplier applied to the ball speed on every hit
+	MaxSpeed       float32
 }
 
-type Ball struct {
-	Rectangle
-	velocity raylib.Vector2
+var tuning = BallTuning{
+	MaxBounceAngle: 60,
+	SpinAngle:      15,
+	SpeedUpPerHit:  1.05,
+	MaxSpeed:       400,
 }
 
-type InputScheme struct {
-	upButton   int32
-	downButton int32
-}
+const (
+	TickRate     = engine.DefaultTickRate
+	TickDuration = 1.0 / TickRate
+)
 
-type Pad struct {
-	Rectangle
-	InputScheme
-	score    int
-	velocity raylib.Vector2
+// Main reads the command line and plays the game on platform. cmd/pong calls it with the raylib backend.
+func Main(platform engine.Platform) {
+	seed := engine.SeedFlag()
+	player1Controller := flag.String("player1", "human", "who plays the left pad: human, easy, medium or hard")
+	player2Controller := flag.String("player2", "human", "who plays the right pad: human, easy, medium or hard")
+	flag.IntVar(&rules.WinScore, "winscore", rules.WinScore, "points needed to win the match")
+	hasSpin := flag.Bool("spin", true, "a moving pad puts spin on the ball")
+	flag.BoolVar(&rules.WinByTwo, "winbytwo", rules.WinByTwo, "the winner has to lead by two points")
+	flag.Parse()
+	rng = engine.NewRand(*seed)
+	if !*hasSpin {
+		tuning.SpinAngle = 0
+	}
+
+	var err error
+	player1.Input, err = NewController(platform, *player1Controller, &player1, engine.KeyW, engine.KeyS)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	player2.Input, err = NewController(platform, *player2Controller, &player2, engine.KeyI, engine.KeyK)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	matchInput = platform.NewKeyboardInput(map[engine.Action]engine.Key{
+		engine.Confirm: engine.KeyEnter,
+		engine.Pause:   engine.KeyP,
+	})
+
+	platform.Run(engine.Game{
+		Title:    "GO Pong",
+		TickRate: TickRate,
+		Setup:    SetupGame,
+		Update:   scenes.Update,
+		Draw:     scenes.Draw,
+	})
 }
 
-var ball Ball
-var player1 Pad
-var player2 Pad
-var players []*Pad = []*Pad{&player1, &player2}
-
-var InitialBallPosition raylib.Vector2
-
-func main() {
-	raylib.InitWindow(800, 450, "GO Pong")
-	defer raylib.CloseWindow()
-	raylib.SetTargetFPS(60)
-
-	screenSizeX := raylib.GetScreenWidth()
-	screenSizeY := raylib.GetScreenHeight()
-
-	InitialBallPosition = raylib.Vector2{float32(screenSizeX / 2), float32(screenSizeY / 2)}
-	ball.velocity = raylib.Vector2{50, 25}
-	ball.centerPosition = InitialBallPosition
-	ball.size = raylib.Vector2{10, 10}
-	player2.size = raylib.Vector2{5, 50}
-	player1.size = raylib.Vector2{5, 50}
-	player2.velocity = raylib.Vector2{100, 100}
-	player1.velocity = raylib.Vector2{100, 100}
-	player1.centerPosition = raylib.Vector2{float32(0 + 5), float32(screenSizeY / 2)}
-	player2.centerPosition = raylib.Vector2{float32(float32(screenSizeX) - player2.size.X - 5), float32(screenSizeY / 2)}
-	player1.InputScheme = InputScheme{
-		raylib.KeyW,
-		raylib.KeyS,
-	}
-	player2.InputScheme = InputScheme{
-		raylib.KeyI,
-		raylib.KeyK,
-	}
-
-	for !raylib.WindowShouldClose() {
-		dt := raylib.GetFrameTime()
-		Update(dt)
-		Draw()
+// NewController returns the platform's keyboard for "human", otherwise a Computer at the named difficulty.
+func NewController(platform engine.Platform, name string, pad *engine.Pad, upKey engine.Key, downKey engine.Key) (engine.Input, error) {
+	if name == "human" {
+		return platform.NewKeyboardInput(map[engine.Action]engine.Key{
+			engine.MoveUp:   upKey,
+			engine.MoveDown: downKey,
+		}), nil
+	}
+	difficulty, ok := Difficulties[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown controller %q, want human, easy, medium or hard", name)
 	}
+	return NewComputer(pad, &ball, difficulty, rng), nil
+}
+
+func SetupGame() {
+	screenSizeX := engine.ScreenWidth
+	screenSizeY := engine.ScreenHeight
+
+	InitialBallPosition = engine.Vector2{float32(screenSizeX / 2), float32(screenSizeY / 2)}
+	ball.Size = engine.Vector2{10, 10}
+	player2.Size = engine.Vector2{5, 50}
+	player1.Size = engine.Vector2{5, 50}
+	player2.Velocity = engine.Vector2{100, 100}
+	player1.Velocity = engine.Vector2{100, 100}
+	player1.Teleport(engine.Vector2{float32(0 + 5), float32(screenSizeY / 2)})
+	player2.Teleport(engine.Vector2{float32(float32(screenSizeX) - player2.Size.X - 5), float32(screenSizeY / 2)})
+
+	SetupScenes()
 }
 
 func Update(deltaTime float32) {
-	height := raylib.GetScreenHeight()
-	width := raylib.GetScreenWidth()
+	height := engine.ScreenHeight
+	width := engine.ScreenWidth
+
+	matchInput.Update()
+	if matchInput.IsPressed(engine.Pause) {
+		scenes.Push(pausedScene)
+		return
+	}
+
 	{ // Update players
-		for _, player := range players {
-			if raylib.IsKeyDown(player.downButton) {
-				// Update position
-				player.centerPosition.Y += (deltaTime * player.velocity.Y)
-				// Clamp on bottom edge
-				if player.centerPosition.Y+(player.size.Y/2) > float32(height) {
-					player.centerPosition.Y = float32(height) - (player.size.Y / 2)
-				}
+		for i, player := range players {
+			player.StartTick()
+			previousY := player.CenterPosition.Y
+			player.Input.Update()
+			if player.Input.IsDown(engine.MoveDown) {
+				player.MoveY(deltaTime*player.Velocity.Y, height)
 			}
-			if raylib.IsKeyDown(player.upButton) {
-				// Update position
-				player.centerPosition.Y -= (deltaTime * player.velocity.Y)
-				// Clamp on top edge
-				if player.centerPosition.Y-(player.size.Y/2) < 0 {
-					player.centerPosition.Y = (player.size.Y / 2)
-				}
+			if player.Input.IsDown(engine.MoveUp) {
+				player.MoveY(-deltaTime*player.Velocity.Y, height)
 			}
+			playerSpeedsY[i] = (player.CenterPosition.Y - previousY) / deltaTime
 		}
 	}
 	{ // Update ball
-		ball.centerPosition.X += deltaTime * ball.velocity.X
-		ball.centerPosition.Y += deltaTime * ball.velocity.Y
+		if serveTarget != nil && engine.HasHitTime(&m_TimerServe, deltaTime) {
+			Serve()
+		}
+		ball.MoveSwept(deltaTime, CollideBallWithPads)
 	}
 	{ // Check collisions
-		for _, player := range players {
-			isDetectBallTouchesPad := DetectBallTouchesPad(ball, player)
-			if isDetectBallTouchesPad {
-				ball.velocity.X *= -1
-			}
-		}
-		isBallOnTopBottomScreenEdge := ball.centerPosition.Y > float32(height) || ball.centerPosition.Y < 0
-		isBallOnRightScreenEdge := ball.centerPosition.X > float32(width)
-		isBallOnLeftScreenEdge := ball.centerPosition.X < 0
+		isBallOnTopBottomScreenEdge := ball.CenterPosition.Y > float32(height) || ball.CenterPosition.Y < 0
+		isBallOnRightScreenEdge := ball.CenterPosition.X > float32(width)
+		isBallOnLeftScreenEdge := ball.CenterPosition.X < 0
 		if isBallOnTopBottomScreenEdge {
-			ball.velocity.Y *= -1
+			ball.Velocity.Y *= -1
 		}
 		if isBallOnLeftScreenEdge {
-			ball.centerPosition = InitialBallPosition
-			player2.score += 1
+			ScorePoint(&player2, &player1)
 		}
 		if isBallOnRightScreenEdge {
-			ball.centerPosition = InitialBallPosition
-			player1.score += 1
+			ScorePoint(&player1, &player2)
+		}
+	}
+}
+
+// CollideBallWithPads finds the first pad the ball would hit along movement and bounces the ball off it.
+func CollideBallWithPads(movement engine.Vector2) (engine.Hit, bool) {
+	nearest := engine.Hit{}
+	nearestPad := -1
+	for i, player := range players {
+		hit, ok := engine.SweptAABB(ball.Rectangle, movement, player.Rectangle)
+		if ok && (nearestPad < 0 || hit.Time < nearest.Time) {
+			nearest = hit
+			nearestPad = i
 		}
 	}
+	if nearestPad < 0 {
+		return engine.Hit{}, false
+	}
+
+	if nearest.Normal.X != 0 {
+		hitY := ball.CenterPosition.Y + movement.Y*nearest.Time
+		DeflectBall(players[nearestPad], playerSpeedsY[nearestPad], hitY)
+	} else {
+		// Clipped the top or bottom end of the pad
+		ball.Velocity = engine.Reflect(ball.Velocity, nearest.Normal)
+	}
+	return nearest, true
+}
+
+// DeflectBall sends the ball back off pad. Where it hit along the pad sets the
+// angle, the pad's movement adds spin, and every hit speeds the ball up to MaxSpeed.
+func DeflectBall(pad *engine.Pad, padSpeedY float32, hitY float32) {
+	hitOffset := (hitY - pad.CenterPosition.Y) / (pad.Size.Y / 2)
+	hitOffset = engine.Max(-1, engine.Min(hitOffset, 1))
+	angle := hitOffset * tuning.MaxBounceAngle
+	angle += tuning.SpinAngle * (padSpeedY / pad.Velocity.Y)
+	angle = engine.Max(-tuning.MaxBounceAngle, engine.Min(angle, tuning.MaxBounceAngle)) * engine.Deg2rad
+
+	speed := engine.Min(engine.Vector2Length(ball.Velocity)*tuning.SpeedUpPerHit, tuning.MaxSpeed)
+	directionX := float32(1)
+	if ball.Velocity.X > 0 {
+		directionX = -1
+	}
+	ball.Velocity = engine.Vector2{
+		directionX * speed * float32(math.Cos(float64(angle))),
+		speed * float32(math.Sin(float64(angle))),
+	}
 }
 
-func Draw() {
-	raylib.BeginDrawing()
-	defer raylib.EndDrawing()
-	raylib.ClearBackground(raylib.Black)
+func Draw(renderer engine.Renderer, alpha float32) {
+	renderer.Clear(engine.Black)
 
 	{ // Draw Court Line
 		var LineThinkness float32 = 2.0
-		x := float32(raylib.GetScreenWidth() / 2.0)
-		from := raylib.Vector2{x, 5.0}
-		to := raylib.Vector2{x, float32(raylib.GetScreenHeight() - 5.0)}
-		raylib.DrawLineEx(from, to, LineThinkness, raylib.LightGray)
+		x := float32(engine.ScreenWidth / 2.0)
+		from := engine.Vector2{x, 5.0}
+		to := engine.Vector2{x, float32(engine.ScreenHeight - 5.0)}
+		renderer.DrawLine(from, to, LineThinkness, engine.LightGray)
 	}
 	{ // Draw Scores
-		DrawText(strconv.Itoa(player1.score), Right, int32(raylib.GetScreenWidth()/2)-10, 10, 20)
-		DrawText(strconv.Itoa(player2.score), Left, int32(raylib.GetScreenWidth()/2)+10, 10, 20)
+		engine.DrawText(renderer, strconv.Itoa(player1.Score), engine.Right, int32(engine.ScreenWidth/2)-10, 10, 20, engine.LightGray)
+		engine.DrawText(renderer, strconv.Itoa(player2.Score), engine.Left, int32(engine.ScreenWidth/2)+10, 10, 20, engine.LightGray)
 	}
 	{ // Draw Players
 		for _, player := range players {
-			raylib.DrawRectangle(int32(player.centerPosition.X-(player.size.X/2)), int32(player.centerPosition.Y-(player.size.Y/2)), int32(player.size.X), int32(player.size.Y), raylib.White)
+			engine.DrawRectangle(renderer, player.Interpolated(alpha), engine.White)
 		}
 	}
 	{ // Draw Ball
-		raylib.DrawRectangle(int32(ball.centerPosition.X-(ball.size.X/2)), int32(ball.centerPosition.Y-(ball.size.Y/2)), int32(ball.size.X), int32(ball.size.Y), raylib.White)
-	}
-}
-
-func DetectBallTouchesPad(ball Ball, pad *Pad) bool {
-	if ball.centerPosition.X >= pad.centerPosition.X && ball.centerPosition.X <= pad.centerPosition.X+pad.size.X {
-		if ball.centerPosition.Y >= pad.centerPosition.Y-(pad.size.Y/2) && ball.centerPosition.Y <= pad.centerPosition.Y+pad.size.Y/2 {
-			return true
-		}
-	}
-	return false
-}
-
-func DrawText(text string, alignment TextAlignment, posX int32, posY int32, fontSize int32) {
-	fontColor := raylib.LightGray
-	if alignment == Left {
-		raylib.DrawText(text, posX, posY, fontSize, fontColor)
-	} else if alignment == Center {
-		scoreSizeLeft := raylib.MeasureText(text, fontSize)
-		raylib.DrawText(text, posX-(scoreSizeLeft/2), posY, fontSize, fontColor)
-	} else if alignment == Right {
-		scoreSizeLeft := raylib.MeasureText(text, fontSize)
-		raylib.DrawText(text, posX-scoreSizeLeft, posY, fontSize, fontColor)
+		engine.DrawRectangle(renderer, ball.Interpolated(alpha), engine.White)
 	}
 }
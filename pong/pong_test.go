@@ -0,0 +1,108 @@
+package pong
+
+import (
+	"hackweek/engine"
+	"reflect"
+	"testing"
+)
+
+// startTestMatch sets the game up with both pads and the menus driven from code.
+func startTestMatch(seed int64, left engine.Input, right engine.Input) *engine.ProgrammaticInput {
+	rng = engine.NewRand(seed)
+	player1.Input = left
+	player2.Input = right
+	menu := &engine.ProgrammaticInput{}
+	matchInput = menu
+	SetupGame()
+	return menu
+}
+
+func TestMatchScenes(t *testing.T) {
+	defer func(saved MatchRules) { rules = saved }(rules)
+	rules.WinScore, rules.WinByTwo = 1, false
+	menu := startTestMatch(1, &engine.ProgrammaticInput{}, &engine.ProgrammaticInput{})
+
+	if frame := scenes.DrawFrame(1); !frame.HasText("GO Pong") || !frame.HasText("Press Enter to start") {
+		t.Fatal("title screen isn't showing")
+	}
+	scenes.Press(menu, engine.Confirm, TickDuration)
+	if frame := scenes.DrawFrame(1); len(frame.CallsOfKind(engine.LineCall)) != 1 || !frame.HasText("0") {
+		t.Fatal("court and scores aren't drawn once playing")
+	}
+
+	scenes.Press(menu, engine.Pause, TickDuration)
+	if scenes.Top() != pausedScene || !scenes.DrawFrame(1).HasText("Paused") {
+		t.Fatal("pause menu isn't showing")
+	}
+	scenes.Press(menu, engine.Pause, TickDuration)
+
+	// Neither pad moves, so the serve goes straight past one of them
+	for tick := 0; tick < 10*TickRate && scenes.Top() == playingScene; tick++ {
+		scenes.Update(TickDuration)
+	}
+	frame := scenes.DrawFrame(1)
+	if !frame.HasText(WinnerBanner()) || len(frame.CallsOfKind(engine.LineCall)) != 1 {
+		t.Fatalf("want the winner over the court, scene is %q", scenes.Top().Name)
+	}
+	scenes.Press(menu, engine.Confirm, TickDuration)
+	if scenes.Top() != playingScene || player1.Score+player2.Score != 0 {
+		t.Fatal("rematch didn't start a new match")
+	}
+}
+
+func TestPadsAreDrawnBetweenTicks(t *testing.T) {
+	left := &engine.ProgrammaticInput{}
+	menu := startTestMatch(1, left, &engine.ProgrammaticInput{})
+	scenes.Press(menu, engine.Confirm, TickDuration)
+
+	// Fast enough to cover whole pixels in one tick
+	player1.Velocity.Y = 10 * TickRate
+	left.Set(engine.MoveDown, true)
+	scenes.Update(TickDuration)
+	padY := func(frame *engine.RecordingRenderer) float32 {
+		for _, call := range frame.CallsOfKind(engine.RectangleCall) {
+			if call.Position.X == 2 && call.Size == player1.Size {
+				return call.Position.Y
+			}
+		}
+		t.Fatal("left pad isn't drawn")
+		return 0
+	}
+	from, halfway, to := padY(scenes.DrawFrame(0)), padY(scenes.DrawFrame(0.5)), padY(scenes.DrawFrame(1))
+	if !(from < to) || halfway < from || halfway > to {
+		t.Errorf("pad drawn at %v, %v and %v for alpha 0, 0.5 and 1", from, halfway, to)
+	}
+}
+
+func TestReplayDrawsTheSameFrames(t *testing.T) {
+	play := func(left engine.Input) []engine.DrawCall {
+		menu := startTestMatch(7, left, &engine.ProgrammaticInput{})
+		scenes.Press(menu, engine.Confirm, TickDuration)
+		for tick := 0; tick < 3*TickRate; tick++ {
+			if source, ok := left.(*engine.RecordingInput); ok {
+				pad := source.Source.(*engine.ProgrammaticInput)
+				pad.Set(engine.MoveUp, tick%100 < 40)
+				pad.Set(engine.MoveDown, tick%100 >= 60)
+			}
+			scenes.Update(TickDuration)
+		}
+		return scenes.DrawFrame(0.5).Calls
+	}
+
+	recording := engine.NewRecordingInput(&engine.ProgrammaticInput{})
+	recorded := play(recording)
+	if len(recording.Events) == 0 {
+		t.Fatal("nothing was recorded")
+	}
+	replay := engine.NewScriptedInput(recording.Events)
+	replayed := play(replay)
+	if !replay.IsFinished() {
+		t.Error("replay didn't play every event")
+	}
+	if !reflect.DeepEqual(recorded, replayed) {
+		t.Error("replay drew a different frame to the recorded game")
+	}
+	if player1.CenterPosition == (engine.Vector2{5, engine.ScreenHeight / 2}) {
+		t.Error("the left pad never moved")
+	}
+}
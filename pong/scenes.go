@@ -0,0 +1,38 @@
+package pong
+
+import (
+	"hackweek/engine"
+)
+
+var scenes engine.SceneStack
+var titleScene *engine.Scene
+var playingScene *engine.Scene
+var pausedScene *engine.Scene
+var matchOverScene *engine.Scene
+
+// SetupScenes builds the title, playing, paused and match over scenes and starts on the title.
+func SetupScenes() {
+	menu := &engine.Menu{
+		Stack:      &scenes,
+		Input:      matchInput,
+		Title:      "GO Pong",
+		Controls:   "W/S and I/K to move, P to pause",
+		Background: engine.Black,
+		Dim:        engine.NewColor(0, 0, 0, 160),
+		Text:       engine.LightGray,
+		Hint:       engine.Gray,
+	}
+	playingScene = &engine.Scene{Name: "playing", Update: Update, Draw: Draw}
+	titleScene = menu.TitleScene(playingScene, StartMatch)
+	pausedScene = menu.PausedScene()
+	matchOverScene = menu.GameOverScene(WinnerBanner, "Press Enter for a rematch", StartMatch)
+	scenes.Switch(titleScene)
+}
+
+// WinnerBanner names the player who won the match.
+func WinnerBanner() string {
+	if winner == &player2 {
+		return "Player 2 Wins"
+	}
+	return "Player 1 Wins"
+}
@@ -0,0 +1,126 @@
+package spaceinvaders
+
+import (
+	"hackweek/engine"
+	"math"
+)
+
+const (
+	NumBunkers         = 4
+	BunkerCellSize     = 4 // Pixels along each side of one bunker cell
+	BunkerTop          = engine.ScreenHeight - 110
+	BunkerCraterChance = 0.5 // Chance each cell around a hit is knocked out with it
+)
+
+// BunkerShape is the bitmap every bunker starts from, '#' for a solid cell.
+var BunkerShape = []string{
+	"  ##########  ",
+	" ############ ",
+	"##############",
+	"##############",
+	"##############",
+	"##############",
+	"##############",
+	"####      ####",
+	"###        ###",
+	"###        ###",
+}
+
+// Bunker is cover made of small cells that are knocked out one by one.
+type Bunker struct {
+	TopLeft engine.Vector2
+	cells   [][]bool // cells[y][x], true while solid
+}
+
+var bunkers [NumBunkers]*Bunker
+
+// SetupBunkers rebuilds every bunker whole, spread evenly across the screen.
+func SetupBunkers() {
+	width := float32(len(BunkerShape[0]) * BunkerCellSize)
+	gap := (float32(engine.ScreenWidth) - NumBunkers*width) / (NumBunkers + 1)
+	for n := range bunkers {
+		bunker := &Bunker{TopLeft: engine.Vector2{gap + float32(n)*(gap+width), BunkerTop}}
+		bunker.cells = make([][]bool, len(BunkerShape))
+		for y, row := range BunkerShape {
+			bunker.cells[y] = make([]bool, len(row))
+			for x := range row {
+				bunker.cells[y][x] = row[x] == '#'
+			}
+		}
+		bunkers[n] = bunker
+	}
+}
+
+func (b *Bunker) CellRectangle(x int, y int) engine.Rectangle {
+	return engine.Rectangle{
+		CenterPosition: engine.Vector2{b.TopLeft.X + float32(x*BunkerCellSize) + BunkerCellSize/2, b.TopLeft.Y + float32(y*BunkerCellSize) + BunkerCellSize/2},
+		Size:           engine.Vector2{BunkerCellSize, BunkerCellSize},
+	}
+}
+
+// cellRange returns the cells r covers, clamped to the bunker. It's empty (min > max) when r misses the bunker.
+func (b *Bunker) cellRange(r engine.Rectangle) (minX int, minY int, maxX int, maxY int) {
+	min, max := r.Min(), r.Max()
+	minX = int(math.Max(math.Floor(float64((min.X-b.TopLeft.X)/BunkerCellSize)), 0))
+	minY = int(math.Max(math.Floor(float64((min.Y-b.TopLeft.Y)/BunkerCellSize)), 0))
+	maxX = int(math.Min(math.Floor(float64((max.X-b.TopLeft.X)/BunkerCellSize)), float64(len(b.cells[0])-1)))
+	maxY = int(math.Min(math.Floor(float64((max.Y-b.TopLeft.Y)/BunkerCellSize)), float64(len(b.cells)-1)))
+	return minX, minY, maxX, maxY
+}
+
+// HitBunkers knocks a ragged hole in the first bunker r overlaps and reports
+// whether it hit anything, so the bullet that made r can be stopped.
+func HitBunkers(r engine.Rectangle) bool {
+	for _, bunker := range bunkers {
+		minX, minY, maxX, maxY := bunker.cellRange(r)
+		isHit := false
+		for y := minY; y <= maxY; y++ {
+			for x := minX; x <= maxX; x++ {
+				if bunker.cells[y][x] {
+					isHit = true
+					bunker.knockOutCrater(x, y)
+				}
+			}
+		}
+		if isHit {
+			return true
+		}
+	}
+	return false
+}
+
+// knockOutCrater clears cell (x, y) and some of the cells around it.
+func (b *Bunker) knockOutCrater(x int, y int) {
+	b.cells[y][x] = false
+	for craterY := y - 1; craterY <= y+1; craterY++ {
+		for craterX := x - 1; craterX <= x+1; craterX++ {
+			if craterY >= 0 && craterY < len(b.cells) && craterX >= 0 && craterX < len(b.cells[craterY]) && rng.Float32() < BunkerCraterChance {
+				b.cells[craterY][craterX] = false
+			}
+		}
+	}
+}
+
+// ErodeBunkers clears every cell r covers, for invaders marching through the bunkers.
+func ErodeBunkers(r engine.Rectangle) {
+	for _, bunker := range bunkers {
+		minX, minY, maxX, maxY := bunker.cellRange(r)
+		for y := minY; y <= maxY; y++ {
+			for x := minX; x <= maxX; x++ {
+				bunker.cells[y][x] = false
+			}
+		}
+	}
+}
+
+func DrawBunkers(renderer engine.Renderer) {
+	for _, bunker := range bunkers {
+		for y := range bunker.cells {
+			for x, isSolid := range bunker.cells[y] {
+				if isSolid {
+					engine.DrawRectangle(renderer, bunker.CellRectangle(x, y), engine.DarkGreen)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,10 @@
+package main
+
+import (
+	"hackweek/engine/backend"
+	"hackweek/spaceinvaders"
+)
+
+func main() {
+	spaceinvaders.Main(backend.Raylib{})
+}
@@ -0,0 +1,156 @@
+package spaceinvaders
+
+import (
+	"hackweek/engine"
+	"math"
+)
+
+type EnemyKind int
+
+const (
+	Octopus EnemyKind = iota
+	Crab
+	Squid
+	ZigZagger
+	Weaver
+	Diver
+	Tank
+	Splitter
+	Splitling
+	NumEnemyKinds
+)
+
+// Movement is how an enemy gets down the screen outside the classic formation.
+type Movement int
+
+const (
+	StraightMovement Movement = iota
+	ZigZagMovement            // Flips sideways every ZigZagSeconds
+	SineMovement              // Weaves either side of where it spawned
+	DiveMovement              // Steers towards the player
+)
+
+type EnemyDefinition struct {
+	Name       string // Used in wave scripts
+	Score      int
+	Color      engine.Color
+	Size       engine.Vector2
+	Movement   Movement
+	HitPoints  int       // Hits to destroy, 0 means 1
+	SplitInto  EnemyKind // What it breaks into when destroyed, if SplitCount > 0
+	SplitCount int
+}
+
+// EnemyDefinitions is indexed by EnemyKind.
+var EnemyDefinitions = [NumEnemyKinds]EnemyDefinition{
+	Octopus:   {Name: "octopus", Score: 10, Color: engine.Blue, Size: engine.Vector2{20, 20}},
+	Crab:      {Name: "crab", Score: 20, Color: engine.DarkGreen, Size: engine.Vector2{20, 20}},
+	Squid:     {Name: "squid", Score: 30, Color: engine.Purple, Size: engine.Vector2{20, 20}},
+	ZigZagger: {Name: "zigzag", Score: 30, Color: engine.Orange, Size: engine.Vector2{20, 20}, Movement: ZigZagMovement},
+	Weaver:    {Name: "sine", Score: 30, Color: engine.SkyBlue, Size: engine.Vector2{20, 20}, Movement: SineMovement},
+	Diver:     {Name: "diver", Score: 40, Color: engine.Red, Size: engine.Vector2{18, 22}, Movement: DiveMovement},
+	Tank:      {Name: "tank", Score: 60, Color: engine.DarkGray, Size: engine.Vector2{30, 24}, HitPoints: 3},
+	Splitter:  {Name: "splitter", Score: 40, Color: engine.Gold, Size: engine.Vector2{24, 24}, SplitInto: Splitling, SplitCount: 3},
+	Splitling: {Name: "splitling", Score: 10, Color: engine.Brown, Size: engine.Vector2{12, 12}},
+}
+
+const (
+	ZigZagSeconds  = 0.8
+	ZigZagSpeedX   = 80 // Sideways speed when the spawn doesn't give one
+	SineAmplitude  = 60
+	SineFrequency  = 0.5 // Weaves a second
+	DiverTurnRate  = 1.5 // Fraction of the gap to the player closed each second
+	DiverMaxSpeedX = 120
+	SplitSpreadX   = 60 // Sideways speed the pieces of a splitter fly apart at
+)
+
+func EnemyKindNamed(name string) (EnemyKind, bool) {
+	for kind, definition := range EnemyDefinitions {
+		if definition.Name == name {
+			return EnemyKind(kind), true
+		}
+	}
+	return 0, false
+}
+
+func (enemy *Enemy) SetKind(kind EnemyKind) {
+	definition := EnemyDefinitions[kind]
+	enemy.kind = kind
+	enemy.color = definition.Color
+	enemy.Size = definition.Size
+	enemy.hitPoints = definition.HitPoints
+	if enemy.hitPoints < 1 {
+		enemy.hitPoints = 1
+	}
+}
+
+// Spawn brings enemy in as a fresh kind at position, moving at velocity.
+func (enemy *Enemy) Spawn(kind EnemyKind, position engine.Vector2, velocity engine.Vector2) {
+	enemy.isActive = true
+	enemy.SetKind(kind)
+	enemy.Teleport(position)
+	enemy.anchorX = position.X
+	enemy.age = 0
+	enemy.velocity = velocity
+	if EnemyDefinitions[kind].Movement == ZigZagMovement && velocity.X == 0 {
+		enemy.velocity.X = ZigZagSpeedX
+	}
+}
+
+// MoveEnemy moves an enemy that isn't in the classic formation by its kind's movement.
+func MoveEnemy(enemy *Enemy, deltaTime float32) {
+	width := float32(engine.ScreenWidth)
+	previousAge := enemy.age
+	enemy.age += deltaTime
+
+	switch EnemyDefinitions[enemy.kind].Movement {
+	case ZigZagMovement:
+		if int(enemy.age/ZigZagSeconds) != int(previousAge/ZigZagSeconds) {
+			enemy.velocity.X *= -1
+		}
+	case DiveMovement:
+		steer := (player1.CenterPosition.X - enemy.CenterPosition.X) * DiverTurnRate
+		enemy.velocity.X = engine.Max(-DiverMaxSpeedX, engine.Min(steer, DiverMaxSpeedX))
+	}
+
+	if EnemyDefinitions[enemy.kind].Movement == SineMovement {
+		enemy.anchorX += enemy.velocity.X * deltaTime
+		weave := math.Sin(float64(enemy.age * SineFrequency * 2 * math.Pi))
+		enemy.CenterPosition.X = enemy.anchorX + SineAmplitude*float32(weave)
+	} else {
+		enemy.CenterPosition.X += enemy.velocity.X * deltaTime
+	}
+	enemy.CenterPosition.Y += enemy.velocity.Y * deltaTime
+
+	// Bounce off the sides
+	if enemy.Min().X < 0 {
+		enemy.velocity.X = engine.Max(enemy.velocity.X, -enemy.velocity.X)
+		enemy.anchorX += -enemy.Min().X
+	}
+	if enemy.Max().X > width {
+		enemy.velocity.X = -engine.Max(enemy.velocity.X, -enemy.velocity.X)
+		enemy.anchorX -= enemy.Max().X - width
+	}
+}
+
+// DamageEnemy takes a hit point off enemy and reports whether that destroyed
+// it. A destroyed splitter breaks into its pieces, which join the wave.
+func DamageEnemy(enemy *Enemy) bool {
+	enemy.hitPoints--
+	if enemy.hitPoints > 0 {
+		return false
+	}
+	enemy.isActive = false
+
+	definition := EnemyDefinitions[enemy.kind]
+	numEnemiesThisLevel += definition.SplitCount
+	numEnemiesToSpawn += definition.SplitCount
+	for n := 0; n < definition.SplitCount; n++ {
+		spread := float32(0)
+		if definition.SplitCount > 1 {
+			spread = SplitSpreadX * (2*float32(n)/float32(definition.SplitCount-1) - 1)
+		}
+		SpawnEnemy(definition.SplitInto, enemy.CenterPosition, engine.Vector2{spread, enemy.velocity.Y})
+	}
+	return true
+}
@@ -0,0 +1,148 @@
+package spaceinvaders
+
+import (
+	"hackweek/engine"
+	"math"
+)
+
+type ProjectileKind int
+
+const (
+	PlainShot ProjectileKind = iota
+	FastShot
+	WigglyShot
+	NumProjectileKinds
+)
+
+type ProjectileDefinition struct {
+	Speed           float32 // Pixels a second down the screen
+	Size            engine.Vector2
+	Color           engine.Color
+	WiggleAmplitude float32 // Pixels either side it weaves, 0 flies straight
+	WiggleFrequency float32 // Weaves a second
+}
+
+// ProjectileDefinitions is indexed by ProjectileKind.
+var ProjectileDefinitions = [NumProjectileKinds]ProjectileDefinition{
+	PlainShot:  {Speed: 150, Size: engine.Vector2{4, 10}, Color: engine.Red},
+	FastShot:   {Speed: 280, Size: engine.Vector2{3, 14}, Color: engine.Maroon},
+	WigglyShot: {Speed: 120, Size: engine.Vector2{6, 6}, Color: engine.Purple, WiggleAmplitude: 12, WiggleFrequency: 2},
+}
+
+const MaxNumEnemyBullets = 20
+
+var EnemyFireIntervalSeconds float32 = 1.2 // Time between enemy shots, see the -firerate flag
+
+type EnemyBullet struct {
+	engine.Mover
+	kind     ProjectileKind
+	isActive bool
+	age      float32
+	firedX   float32
+}
+
+var enemyBullets [MaxNumEnemyBullets]*EnemyBullet
+var m_TimerEnemyFire float32
+
+func SetupEnemyBullets() {
+	for i := 0; i < MaxNumEnemyBullets; i++ {
+		enemyBullets[i] = new(EnemyBullet)
+	}
+	m_TimerEnemyFire = EnemyFireIntervalSeconds
+}
+
+func ClearEnemyBullets() {
+	for i := 0; i < MaxNumEnemyBullets; i++ {
+		enemyBullets[i].isActive = false
+	}
+}
+
+// PickShooter returns the invader to fire next, or nil if none can. In classic
+// mode only the bottom invader of a column can shoot, otherwise any invader on screen can.
+func PickShooter() *Enemy {
+	var shooters []*Enemy
+	if isClassicMode {
+		for column := 0; column < FormationColumns; column++ {
+			for row := FormationRows - 1; row >= 0; row-- {
+				if enemy := enemies[row*FormationColumns+column]; enemy.isActive {
+					shooters = append(shooters, enemy)
+					break
+				}
+			}
+		}
+	} else {
+		for _, enemy := range enemies {
+			if enemy.isActive && enemy.CenterPosition.Y > 0 {
+				shooters = append(shooters, enemy)
+			}
+		}
+	}
+	if len(shooters) == 0 {
+		return nil
+	}
+	return shooters[rng.Intn(len(shooters))]
+}
+
+func FireEnemyBullet(shooter *Enemy, kind ProjectileKind) {
+	for i := 0; i < MaxNumEnemyBullets; i++ {
+		bullet := enemyBullets[i]
+		if bullet.isActive {
+			continue
+		}
+		bullet.isActive = true
+		bullet.kind = kind
+		bullet.age = 0
+		bullet.firedX = shooter.CenterPosition.X
+		bullet.Size = ProjectileDefinitions[kind].Size
+		bullet.Teleport(engine.Vector2{shooter.CenterPosition.X, shooter.Max().Y})
+		return
+	}
+}
+
+func UpdateEnemyBullets(deltaTime float32) {
+	{ // Fire
+		if engine.HasHitInterval(&m_TimerEnemyFire, EnemyFireIntervalSeconds, deltaTime) {
+			if shooter := PickShooter(); shooter != nil {
+				FireEnemyBullet(shooter, ProjectileKind(rng.Intn(int(NumProjectileKinds))))
+			}
+		}
+	}
+	{ // Move
+		for i := 0; i < MaxNumEnemyBullets; i++ {
+			bullet := enemyBullets[i]
+			if !bullet.isActive {
+				continue
+			}
+			definition := ProjectileDefinitions[bullet.kind]
+			bullet.age += deltaTime
+			bullet.CenterPosition.Y += definition.Speed * deltaTime
+			wiggle := math.Sin(float64(bullet.age * definition.WiggleFrequency * 2 * math.Pi))
+			bullet.CenterPosition.X = bullet.firedX + definition.WiggleAmplitude*float32(wiggle)
+
+			// Went off screen
+			if bullet.Min().Y >= engine.ScreenHeight {
+				bullet.isActive = false
+				continue
+			}
+			// enemy bullet | bunker collision
+			if HitBunkers(bullet.Rectangle) {
+				bullet.isActive = false
+				continue
+			}
+			// enemy bullet | player collision
+			if bullet.Overlaps(player1.Rectangle) {
+				bullet.isActive = false
+				KillPlayer()
+			}
+		}
+	}
+}
+
+func DrawEnemyBullets(renderer engine.Renderer, alpha float32) {
+	for i := 0; i < MaxNumEnemyBullets; i++ {
+		bullet := enemyBullets[i]
+		if bullet.isActive {
+			engine.DrawRectangle(renderer, bullet.Interpolated(alpha), ProjectileDefinitions[bullet.kind].Color)
+		}
+	}
+}
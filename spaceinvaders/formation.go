@@ -0,0 +1,92 @@
+package spaceinvaders
+
+import (
+	"hackweek/engine"
+)
+
+// The classic mode formation, the same size every wave.
+const (
+	FormationRows     = 5
+	FormationColumns  = 10
+	FormationSpacingX = 40
+	FormationSpacingY = 32
+	FormationTop      = 50
+	FormationStepDown = 16
+	FormationMinSpeed = 20  // Pixels a second sideways with the whole formation alive on the first wave
+	FormationMaxSpeed = 240 // Pixels a second sideways with one invader left
+)
+
+var isClassicMode bool
+var formationDirection float32 = 1 // 1 marching right, -1 marching left
+
+// SetupFormation lines every invader up in rows and columns at the top of the screen.
+func SetupFormation() {
+	EnsureEnemyPool(FormationRows * FormationColumns)
+	formationWidth := float32((FormationColumns - 1) * FormationSpacingX)
+	left := (float32(engine.ScreenWidth) - formationWidth) / 2
+	for row := 0; row < FormationRows; row++ {
+		for column := 0; column < FormationColumns; column++ {
+			enemy := enemies[row*FormationColumns+column]
+			enemy.isActive = true
+			enemy.SetKind(FormationRowKind(row))
+			enemy.Teleport(engine.Vector2{left + float32(column*FormationSpacingX), float32(FormationTop + row*FormationSpacingY)})
+		}
+	}
+	formationDirection = 1
+	numEnemiesThisLevel = FormationRows * FormationColumns
+	numEnemiesToSpawn = 0
+}
+
+// FormationRowKind puts the squids on the top row, crabs in the next two and octopuses at the bottom.
+func FormationRowKind(row int) EnemyKind {
+	switch {
+	case row == 0:
+		return Squid
+	case row < 3:
+		return Crab
+	default:
+		return Octopus
+	}
+}
+
+// FormationSpeed goes up as invaders die, reaching FormationMaxSpeed for the
+// last one, and goes up again with every wave.
+func FormationSpeed() float32 {
+	total := FormationRows * FormationColumns
+	fractionKilled := float32(numEnemiesKilled) / float32(total-1)
+	speed := FormationMinSpeed + (FormationMaxSpeed-FormationMinSpeed)*engine.Min(fractionKilled, 1)
+	return speed * (1 + FormationSpeedUpPerWave*float32(wave-1))
+}
+
+// UpdateFormation marches the formation sideways. When any invader reaches the
+// edge of the screen the whole formation steps down and turns around. The game
+// is over once an invader gets down to the player's row.
+func UpdateFormation(deltaTime float32) {
+	width := float32(engine.ScreenWidth)
+
+	// Move first and then pull back by however far the outermost invader went past the edge
+	overshoot := float32(0)
+	for i := 0; i < numEnemiesThisLevel; i++ {
+		enemy := enemies[i]
+		if !enemy.isActive {
+			continue
+		}
+		enemy.CenterPosition.X += formationDirection * FormationSpeed() * deltaTime
+		overshoot = engine.Max(overshoot, engine.Max(-enemy.Min().X, enemy.Max().X-width))
+	}
+	if overshoot > 0 {
+		for i := 0; i < numEnemiesThisLevel; i++ {
+			enemy := enemies[i]
+			enemy.CenterPosition.X -= formationDirection * overshoot
+			enemy.CenterPosition.Y += FormationStepDown
+		}
+		formationDirection *= -1
+	}
+
+	for i := 0; i < numEnemiesThisLevel; i++ {
+		enemy := enemies[i]
+		if enemy.isActive && enemy.Max().Y >= player1.Min().Y {
+			IsGameOver = true
+		}
+	}
+}
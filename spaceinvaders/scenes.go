@@ -0,0 +1,30 @@
+package spaceinvaders
+
+import (
+	"hackweek/engine"
+)
+
+var scenes engine.SceneStack
+var titleScene *engine.Scene
+var playingScene *engine.Scene
+var pausedScene *engine.Scene
+var gameOverScene *engine.Scene
+
+// SetupScenes builds the title, playing, paused and game over scenes and starts on the title.
+func SetupScenes() {
+	menu := &engine.Menu{
+		Stack:      &scenes,
+		Input:      player1.Input,
+		Title:      "GO Space Invaders",
+		Controls:   "A/D to move, Space to shoot, P to pause",
+		Background: engine.White,
+		Dim:        engine.NewColor(255, 255, 255, 160),
+		Text:       engine.DarkGray,
+		Hint:       engine.Gray,
+	}
+	playingScene = &engine.Scene{Name: "playing", Update: Update, Draw: Draw}
+	titleScene = menu.TitleScene(playingScene, StartGame)
+	pausedScene = menu.PausedScene()
+	gameOverScene = menu.GameOverScene(func() string { return "Game Over" }, "Press Enter to play again", StartGame)
+	scenes.Switch(titleScene)
+}
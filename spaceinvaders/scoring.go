@@ -0,0 +1,117 @@
+package spaceinvaders
+
+import (
+	"hackweek/engine"
+	"strconv"
+)
+
+const (
+	ComboHitsPerMultiplier = 5 // Hits in a row for each step up in multiplier
+	MaxComboMultiplier     = 4
+)
+
+var combo int // Player bullets in a row that hit something worth points
+
+func ComboMultiplier() int {
+	multiplier := 1 + combo/ComboHitsPerMultiplier
+	if multiplier > MaxComboMultiplier {
+		return MaxComboMultiplier
+	}
+	return multiplier
+}
+
+// ScorePoints adds points times the combo multiplier and extends the combo.
+func ScorePoints(points int) int {
+	scored := points * ComboMultiplier()
+	player1.Score += scored
+	combo++
+	return scored
+}
+
+// MissShot ends the combo when a player bullet hits nothing worth points.
+func MissShot() {
+	combo = 0
+}
+
+// The mystery ship crosses the top of the screen every so often for bonus points.
+const (
+	UfoMinIntervalSeconds = 15
+	UfoMaxIntervalSeconds = 30
+	UfoSpeed              = 90
+	UfoTop                = 36
+	BonusTextSeconds      = 1.5
+)
+
+// UfoBonusScores are the points a mystery ship can be worth, picked at random when it's hit.
+var UfoBonusScores = []int{50, 100, 150, 300}
+
+type Ufo struct {
+	engine.Mover
+	velocityX float32
+	isActive  bool
+}
+
+var ufo Ufo
+var m_TimerUfo float32
+var bonusText string
+var bonusTextPosition engine.Vector2
+var m_TimerBonusText float32
+
+func ResetUfo() {
+	ufo = Ufo{}
+	ufo.Size = engine.Vector2{36, 14}
+	m_TimerUfo = UfoMinIntervalSeconds + rng.Float32()*(UfoMaxIntervalSeconds-UfoMinIntervalSeconds)
+}
+
+func UpdateUfo(deltaTime float32) {
+	engine.HasHitTime(&m_TimerBonusText, deltaTime)
+
+	{ // Launch from a random side
+		if !ufo.isActive && engine.HasHitTime(&m_TimerUfo, deltaTime) {
+			ResetUfo()
+			ufo.isActive = true
+			ufo.velocityX = UfoSpeed
+			ufo.Teleport(engine.Vector2{-ufo.Size.X / 2, UfoTop})
+			if rng.Intn(2) == 0 {
+				ufo.velocityX = -UfoSpeed
+				ufo.Teleport(engine.Vector2{engine.ScreenWidth + ufo.Size.X/2, UfoTop})
+			}
+		}
+	}
+	if !ufo.isActive {
+		return
+	}
+	{ // Movement
+		ufo.CenterPosition.X += ufo.velocityX * deltaTime
+		if ufo.Max().X < 0 || ufo.Min().X > engine.ScreenWidth {
+			ufo.isActive = false
+		}
+	}
+	{ // bullet | ufo collision
+		for i := 0; i < MaxNumBullets; i++ {
+			bullet := bullets[i]
+			if bullet.isActive && bullet.Overlaps(ufo.Rectangle) {
+				bullet.isActive = false
+				ufo.isActive = false
+				scored := ScorePoints(UfoBonusScores[rng.Intn(len(UfoBonusScores))])
+				ShowBonusText(strconv.Itoa(scored), ufo.CenterPosition)
+				break
+			}
+		}
+	}
+}
+
+func ShowBonusText(text string, position engine.Vector2) {
+	bonusText = text
+	bonusTextPosition = position
+	m_TimerBonusText = BonusTextSeconds
+}
+
+func DrawUfo(renderer engine.Renderer, alpha float32) {
+	if ufo.isActive {
+		engine.DrawRectangle(renderer, ufo.Interpolated(alpha), engine.Red)
+	}
+	if m_TimerBonusText > 0 {
+		engine.DrawText(renderer, bonusText, engine.Center, int32(bonusTextPosition.X), int32(bonusTextPosition.Y)-10, 20, engine.Red)
+	}
+}
@@ -0,0 +1,255 @@
+package spaceinvaders
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"hackweek/engine"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// A wave script is a list of waves, each a timeline of spawn events:
+//
+//	# Comments and blank lines are ignored
+//	wave: Scouts
+//	spawn: time=0 kind=octopus x=random
+//	spawn: time=2 kind=crab x=spread count=4 vy=50
+//	spawn: time=5 kind=squid x=400 count=3 vx=20
+//
+// Every spawn needs time, seconds after the wave starts, and kind, a name from
+// EnemyDefinitions. x is a pixel position, random, or spread to line count
+// enemies up evenly across the screen; count enemies at the same x are stacked
+// one above the other. vx and vy are the velocity in pixels a second. When the
+// script runs out it starts over from the first wave, and every time it does
+// the enemies are faster, each spawn brings more of them and they come sooner.
+
+const (
+	DefaultSpawnSpeed     = 40
+	SpawnTop              = -20  // Enemies appear just above the screen
+	SpawnStackSpacing     = 30   // Pixels between enemies stacked at the same x
+	ScriptRepeatSpeedUp   = 0.25 // Fraction added to spawn velocities every time the script starts over
+	ScriptRepeatCountUp   = 0.5  // Fraction of each spawn count, rounded up, added every time the script starts over
+	ScriptRepeatTimeScale = 0.85 // Spawn times are multiplied by this every time the script starts over
+	MinScriptTimeScale    = 0.4
+)
+
+type SpawnPattern int
+
+const (
+	AtX     SpawnPattern = iota // All at SpawnEvent.X
+	RandomX                     // Each at its own random x
+	SpreadX                     // Evenly across the screen
+)
+
+type SpawnEvent struct {
+	Time     float32 // Seconds after the wave starts
+	Kind     EnemyKind
+	Pattern  SpawnPattern
+	X        float32
+	Count    int
+	Velocity engine.Vector2
+}
+
+type WaveScript struct {
+	Name   string
+	Events []SpawnEvent // In time order
+}
+
+// LoopedCount is how many enemies the event spawns once the script has started over loops times.
+func (e *SpawnEvent) LoopedCount(loops int) int {
+	return e.Count + loops*int(math.Ceil(float64(e.Count)*ScriptRepeatCountUp))
+}
+
+// NumEnemies is how many enemies the wave spawns in total once the script has started over loops times.
+func (w *WaveScript) NumEnemies(loops int) int {
+	total := 0
+	for n := range w.Events {
+		total += w.Events[n].LoopedCount(loops)
+	}
+	return total
+}
+
+//go:embed waves.txt
+var bundledWaves string
+
+func LoadBundledWaves() ([]*WaveScript, error) {
+	return ParseWaves("waves.txt", strings.NewReader(bundledWaves))
+}
+
+func LoadWaves(filename string) ([]*WaveScript, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ParseWaves(filename, file)
+}
+
+// ParseWaves reads a wave script, reporting the first problem as a *engine.ParseError. filename is only used in errors.
+func ParseWaves(filename string, r io.Reader) ([]*WaveScript, error) {
+	fail := func(line int, column int, format string, args ...interface{}) ([]*WaveScript, error) {
+		return nil, &engine.ParseError{File: filename, Line: line, Column: column, Message: fmt.Sprintf(format, args...)}
+	}
+
+	var waves []*WaveScript
+	waveLine := 0 // Line of the last "wave:", to point at a wave with nothing in it
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		text := strings.TrimRight(scanner.Text(), " \t\r")
+		trimmed := strings.TrimSpace(text)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		colon := strings.Index(text, ":")
+		if colon < 0 {
+			return fail(lineNumber, 1, "expected \"wave:\" or \"spawn:\", got %q", trimmed)
+		}
+		key := strings.TrimSpace(text[:colon])
+		value := strings.TrimSpace(text[colon+1:])
+
+		switch key {
+		case "wave":
+			if len(waves) > 0 && len(waves[len(waves)-1].Events) == 0 {
+				return fail(waveLine, 1, "wave has no spawns")
+			}
+			waves = append(waves, &WaveScript{Name: value})
+			waveLine = lineNumber
+		case "spawn":
+			if len(waves) == 0 {
+				return fail(lineNumber, 1, "spawn before the first wave")
+			}
+			event, err := parseSpawn(text, colon+1)
+			if err != nil {
+				err.File, err.Line = filename, lineNumber
+				return nil, err
+			}
+			wave := waves[len(waves)-1]
+			wave.Events = append(wave.Events, event)
+		default:
+			return fail(lineNumber, 1, "unknown key %q", key)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(waves) == 0 {
+		return fail(lineNumber+1, 1, "no waves")
+	}
+	if len(waves[len(waves)-1].Events) == 0 {
+		return fail(waveLine, 1, "wave has no spawns")
+	}
+	for _, wave := range waves {
+		sort.SliceStable(wave.Events, func(a int, b int) bool {
+			return wave.Events[a].Time < wave.Events[b].Time
+		})
+	}
+	return waves, nil
+}
+
+// parseSpawn reads the key=value fields of a spawn line starting at text[start:].
+// The error it returns only has Column and Message set.
+func parseSpawn(text string, start int) (SpawnEvent, *engine.ParseError) {
+	event := SpawnEvent{Pattern: RandomX, Count: 1, Velocity: engine.Vector2{0, DefaultSpawnSpeed}}
+	fail := func(column int, format string, args ...interface{}) (SpawnEvent, *engine.ParseError) {
+		return SpawnEvent{}, &engine.ParseError{Column: column, Message: fmt.Sprintf(format, args...)}
+	}
+
+	seenKeys := map[string]bool{}
+	for index := start; index < len(text); {
+		if unicode.IsSpace(rune(text[index])) {
+			index++
+			continue
+		}
+		end := index
+		for end < len(text) && !unicode.IsSpace(rune(text[end])) {
+			end++
+		}
+		field := text[index:end]
+		column := index + 1
+		valueColumn := column
+		index = end
+
+		equals := strings.Index(field, "=")
+		if equals < 0 {
+			return fail(column, "expected key=value, got %q", field)
+		}
+		key, value := field[:equals], field[equals+1:]
+		valueColumn += equals + 1
+		if seenKeys[key] {
+			return fail(column, "%s is set twice", key)
+		}
+		seenKeys[key] = true
+
+		switch key {
+		case "time":
+			time, err := strconv.ParseFloat(value, 32)
+			if err != nil || !isFinite(time) || time < 0 {
+				return fail(valueColumn, "time must be a number of seconds, 0 or more, got %q", value)
+			}
+			event.Time = float32(time)
+		case "kind":
+			kind, ok := EnemyKindNamed(value)
+			if !ok {
+				return fail(valueColumn, "unknown enemy kind %q", value)
+			}
+			event.Kind = kind
+		case "x":
+			switch value {
+			case "random":
+				event.Pattern = RandomX
+			case "spread":
+				event.Pattern = SpreadX
+			default:
+				x, err := strconv.ParseFloat(value, 32)
+				if err != nil || !isFinite(x) || x < 0 || x > float64(engine.ScreenWidth) {
+					return fail(valueColumn, "x must be random, spread or 0 to %d, got %q", engine.ScreenWidth, value)
+				}
+				event.Pattern = AtX
+				event.X = float32(x)
+			}
+		case "count":
+			count, err := strconv.Atoi(value)
+			if err != nil || count < 1 {
+				return fail(valueColumn, "count must be a whole number, 1 or more, got %q", value)
+			}
+			event.Count = count
+		case "vx", "vy":
+			speed, err := strconv.ParseFloat(value, 32)
+			if err != nil || !isFinite(speed) {
+				return fail(valueColumn, "%s must be a number, got %q", key, value)
+			}
+			if key == "vx" {
+				event.Velocity.X = float32(speed)
+			} else if speed <= 0 {
+				// Enemies only leave the wave by reaching the bottom, so they have to head down
+				return fail(valueColumn, "vy must be more than 0, got %q", value)
+			} else {
+				event.Velocity.Y = float32(speed)
+			}
+		default:
+			return fail(column, "unknown key %q", key)
+		}
+	}
+
+	if !seenKeys["time"] {
+		return fail(start+1, "spawn is missing time")
+	}
+	if !seenKeys["kind"] {
+		return fail(start+1, "spawn is missing kind")
+	}
+	return event, nil
+}
+
+func isFinite(f float64) bool {
+	return !math.IsNaN(f) && !math.IsInf(f, 0)
+}
@@ -0,0 +1,67 @@
+package spaceinvaders
+
+import (
+	"errors"
+	"hackweek/engine"
+	"strings"
+	"testing"
+)
+
+func TestParseWavesErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		line   int
+		column int
+	}{
+		{"no waves", "# Nothing\n", 2, 1},
+		{"not a key", "wave: A\nspawn time=0 kind=crab\n", 2, 1},
+		{"unknown key", "wave: A\nenemy: crab\n", 2, 1},
+		{"spawn before wave", "spawn: time=0 kind=crab\n", 1, 1},
+		{"empty wave", "wave: A\nwave: B\nspawn: time=0 kind=crab\n", 1, 1},
+		{"empty last wave", "wave: A\nspawn: time=0 kind=crab\n\nwave: B\n", 4, 1},
+		{"field without value", "wave: A\nspawn: time=0 crab\n", 2, 15},
+		{"field twice", "wave: A\nspawn: time=0 kind=crab time=1\n", 2, 25},
+		{"unknown field", "wave: A\nspawn: time=0 kind=crab speed=3\n", 2, 25},
+		{"bad time", "wave: A\nspawn: time=soon kind=crab\n", 2, 13},
+		{"time not a number", "wave: A\nspawn: time=NaN kind=crab\n", 2, 13},
+		{"time forever", "wave: A\nspawn: time=+Inf kind=crab\n", 2, 13},
+		{"unknown kind", "wave: A\nspawn: time=0  kind=dragon\n", 2, 21},
+		{"bad x", "wave: A\nspawn: time=0 kind=crab x=left\n", 2, 27},
+		{"bad count", "wave: A\nspawn: time=0 kind=crab count=0\n", 2, 31},
+		{"bad velocity", "wave: A\nspawn: time=0 kind=crab vy=fast\n", 2, 28},
+		{"velocity not a number", "wave: A\nspawn: time=0 kind=crab vx=nan\n", 2, 28},
+		{"x not a number", "wave: A\nspawn: time=0 kind=crab x=NaN\n", 2, 27},
+		{"standing still", "wave: A\nspawn: time=0 kind=crab vy=0\n", 2, 28},
+		{"heading up", "wave: A\nspawn: time=0 kind=crab vy=-40\n", 2, 28},
+		{"missing time", "wave: A\nspawn: kind=crab\n", 2, 7},
+		{"missing kind", "wave: A\nspawn: time=1\n", 2, 7},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParseWaves("test.txt", strings.NewReader(test.text))
+			var parseError *engine.ParseError
+			if !errors.As(err, &parseError) {
+				t.Fatalf("got %v, want a *engine.ParseError", err)
+			}
+			if parseError.File != "test.txt" || parseError.Line != test.line || parseError.Column != test.column {
+				t.Errorf("got %v, want test.txt:%d:%d", err, test.line, test.column)
+			}
+		})
+	}
+}
+
+func TestParseBundledWaves(t *testing.T) {
+	waves, err := LoadBundledWaves()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for n, wave := range waves {
+		if wave.NumEnemies(0) == 0 {
+			t.Errorf("wave %q spawns nothing", wave.Name)
+		}
+		if n > 0 && wave.NumEnemies(0) <= waves[n-1].NumEnemies(0) {
+			t.Errorf("wave %q spawns %d enemies, no more than the %d of %q before it", wave.Name, wave.NumEnemies(0), waves[n-1].NumEnemies(0), waves[n-1].Name)
+		}
+	}
+}
@@ -0,0 +1,317 @@
+// Package spaceinvaders is the game of space invaders, in scripted waves or the classic formation.
+// The program that opens a window for it is cmd/spaceinvaders.
+package spaceinvaders
+
+import (
+	"flag"
+	"fmt"
+	"hackweek/engine"
+	"math/rand"
+	"os"
+	"strconv"
+)
+
+const (
+	BulletCooldownSeconds = 0.3
+	MaxNumBullets         = 50
+	StartingLives         = 3
+	InitialNumEnemies     = 50 // The enemy pool grows past this when a wave needs more
+)
+
+type Bullet struct {
+	engine.Mover
+	velocity engine.Vector2
+	isActive bool
+	color    engine.Color
+}
+type Enemy struct {
+	engine.Mover
+	velocity  engine.Vector2
+	isActive  bool
+	color     engine.Color
+	kind      EnemyKind
+	hitPoints int
+	age       float32 // Seconds since it spawned
+	anchorX   float32 // Where a weaving enemy weaves around
+}
+
+var bullets [MaxNumBullets]*Bullet
+var enemies []*Enemy
+var player1 engine.Pad
+var rng *rand.Rand
+var m_TimerBulletCooldown float32
+var numEnemiesThisLevel int
+var numEnemiesToSpawn int
+var numEnemiesKilled int
+var numLives int
+var IsGameOver bool
+
+var InitialPlayerPosition engine.Vector2
+
+// Main reads the command line and plays the game on platform. cmd/spaceinvaders calls it with the raylib backend.
+func Main(platform engine.Platform) {
+	seed := engine.SeedFlag()
+	fireRate := flag.Float64("firerate", float64(EnemyFireIntervalSeconds), "seconds between enemy shots, 0 turns enemy fire off")
+	wavesFile := flag.String("waves", "", "spawn waves from this script file instead of the bundled one")
+	flag.BoolVar(&isClassicMode, "classic", false, "invaders march in a formation instead of falling from random places")
+	flag.Parse()
+	rng = engine.NewRand(*seed)
+	EnemyFireIntervalSeconds = float32(*fireRate)
+
+	var err error
+	if *wavesFile != "" {
+		waveScripts, err = LoadWaves(*wavesFile)
+	} else {
+		waveScripts, err = LoadBundledWaves()
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	player1.Input = platform.NewKeyboardInput(map[engine.Action]engine.Key{
+		engine.MoveLeft:  engine.KeyA,
+		engine.MoveRight: engine.KeyD,
+		engine.Shoot:     engine.KeySpace,
+		engine.Confirm:   engine.KeyEnter,
+		engine.Pause:     engine.KeyP,
+	})
+
+	platform.Run(engine.Game{
+		Title:  "GO Space Invaders",
+		Setup:  SetupGame,
+		Update: scenes.Update,
+		Draw:   scenes.Draw,
+	})
+}
+
+func SetupGame() {
+	screenSizeX := engine.ScreenWidth
+	screenSizeY := engine.ScreenHeight
+	InitialPlayerPosition = engine.Vector2{float32(screenSizeX / 2), float32(screenSizeY - 10)}
+
+	{ // Set up player
+		player1.Size = engine.Vector2{25, 25}
+		player1.Velocity = engine.Vector2{100, 100}
+	}
+	{ // init bullets
+		for i := 0; i < MaxNumBullets; i++ {
+			bullets[i] = new(Bullet)
+			{
+				bullets[i].velocity = engine.Vector2{0, 400}
+				bullets[i].Rectangle = engine.Rectangle{Size: engine.Vector2{5, 5}}
+			}
+		}
+	}
+	{ // init enemy bullets
+		SetupEnemyBullets()
+	}
+	{ // init enemies
+		EnsureEnemyPool(InitialNumEnemies)
+	}
+
+	SetupScenes()
+}
+
+// StartGame starts over from the first wave with a full set of lives and no score.
+func StartGame() {
+	numLives = StartingLives
+	IsGameOver = false
+	player1.Teleport(InitialPlayerPosition)
+	player1.Score = 0
+	combo = 0
+	ResetUfo()
+	StartWave(1)
+}
+
+func Update(deltaTime float32) {
+	height := engine.ScreenHeight
+	width := engine.ScreenWidth
+
+	player1.Input.Update()
+	if player1.Input.IsPressed(engine.Pause) {
+		scenes.Push(pausedScene)
+		return
+	}
+	engine.HasHitTime(&m_TimerWaveBanner, deltaTime)
+
+	{ // Remember where everything was, for drawing between ticks
+		player1.StartTick()
+		for i := 0; i < MaxNumBullets; i++ {
+			bullets[i].StartTick()
+		}
+		for _, enemy := range enemies {
+			enemy.StartTick()
+		}
+		for i := 0; i < MaxNumEnemyBullets; i++ {
+			enemyBullets[i].StartTick()
+		}
+		ufo.StartTick()
+	}
+
+	{ // Update Player
+		if player1.Input.IsDown(engine.MoveRight) {
+			player1.MoveX(deltaTime*player1.Velocity.X, width)
+		}
+		if player1.Input.IsDown(engine.MoveLeft) {
+			player1.MoveX(-deltaTime*player1.Velocity.X, width)
+		}
+		if engine.HasHitTime(&m_TimerBulletCooldown, deltaTime) {
+			if player1.Input.IsDown(engine.Shoot) {
+				for i := 0; i < MaxNumBullets; i++ {
+					if !bullets[i].isActive {
+						m_TimerBulletCooldown = BulletCooldownSeconds
+						bullets[i].isActive = true
+						{
+							bullets[i].Teleport(engine.Vector2{player1.CenterPosition.X, player1.CenterPosition.Y + (player1.Size.Y / 4)})
+							break
+						}
+					}
+				}
+			}
+		}
+	}
+	{ // Update active bullets
+		for i := 0; i < MaxNumBullets; i++ {
+			bullet := bullets[i]
+			// Movement
+			if bullet.isActive {
+				bullet.CenterPosition.Y -= bullet.velocity.Y * deltaTime
+
+				// Went off screen
+				if bullet.CenterPosition.Y+(bullet.Size.Y/2) <= 0 {
+					bullet.isActive = false
+					MissShot()
+				}
+				// bullet | bunker collision
+				if bullet.isActive && HitBunkers(bullet.Rectangle) {
+					bullet.isActive = false
+					MissShot()
+				}
+			}
+		}
+	}
+	{ // Update formation
+		if isClassicMode {
+			UpdateFormation(deltaTime)
+		}
+	}
+	{ // Update active enemies
+		for i := 0; i < len(enemies); i++ {
+			enemy := enemies[i]
+			// Movement
+			if enemy.isActive {
+				if !isClassicMode {
+					MoveEnemy(enemy, deltaTime)
+				}
+				ErodeBunkers(enemy.Rectangle)
+
+				// Went off screen
+				if enemy.CenterPosition.Y-(enemy.Size.Y/2) >= float32(height) {
+					enemy.Teleport(engine.Vector2{float32(rng.Intn(width)), -20})
+					enemy.anchorX = enemy.CenterPosition.X
+				} else {
+					{ // bullet | enemy collision
+						for j := 0; j < MaxNumBullets; j++ {
+							bullet := bullets[j]
+							if bullet.isActive && bullet.Overlaps(enemy.Rectangle) {
+								bullet.isActive = false
+								if DamageEnemy(enemy) {
+									numEnemiesKilled++
+									ScorePoints(EnemyDefinitions[enemy.kind].Score)
+								}
+								break
+							}
+						}
+					}
+					{ // player | enemy collision
+						if player1.Overlaps(enemy.Rectangle) {
+							enemy.isActive = false
+							numEnemiesKilled++
+							KillPlayer()
+						}
+					}
+				}
+			}
+		}
+	}
+	{ // Update mystery ship
+		UpdateUfo(deltaTime)
+	}
+	{ // Update enemy bullets
+		if EnemyFireIntervalSeconds > 0 {
+			UpdateEnemyBullets(deltaTime)
+		}
+	}
+	{ // Spawn enemies
+		if waveScript != nil {
+			UpdateWaveScript(deltaTime)
+		}
+	}
+	{ // Next wave
+		if IsWaveCleared() && !IsGameOver {
+			StartWave(wave + 1)
+		}
+	}
+	{ // Game over
+		if IsGameOver {
+			scenes.Push(gameOverScene)
+		}
+	}
+}
+
+func Draw(renderer engine.Renderer, alpha float32) {
+	renderer.Clear(engine.White)
+
+	height := int32(engine.ScreenHeight)
+	width := int32(engine.ScreenWidth)
+
+	{ // Draw the bunkers
+		DrawBunkers(renderer)
+	}
+	{ // Draw Players
+		engine.DrawRectangle(renderer, player1.Interpolated(alpha), engine.Black)
+	}
+	{ // Draw the bullets
+		for i := 0; i < MaxNumBullets; i++ {
+			bullet := bullets[i]
+			if bullet.isActive {
+				engine.DrawRectangle(renderer, bullet.Interpolated(alpha), engine.Orange)
+			}
+		}
+	}
+	{ // Draw the enemy bullets
+		DrawEnemyBullets(renderer, alpha)
+	}
+	{ // Draw the enemies
+		for i := 0; i < len(enemies); i++ {
+			enemy := enemies[i]
+			if enemy.isActive {
+				engine.DrawRectangle(renderer, enemy.Interpolated(alpha), enemy.color)
+			}
+		}
+	}
+	{ // Draw the mystery ship
+		DrawUfo(renderer, alpha)
+	}
+	{ // Draw Info
+		engine.DrawText(renderer, "Lives "+strconv.Itoa(numLives), engine.Left, 15, 5, 20, engine.DarkGray)
+		engine.DrawText(renderer, "Score "+strconv.Itoa(player1.Score), engine.Center, width/2, 5, 20, engine.DarkGray)
+		engine.DrawText(renderer, "Wave "+strconv.Itoa(wave), engine.Right, width-15, 5, 20, engine.DarkGray)
+		if multiplier := ComboMultiplier(); multiplier > 1 {
+			engine.DrawText(renderer, "x"+strconv.Itoa(multiplier), engine.Center, width/2, 25, 10, engine.Orange)
+		}
+
+		if m_TimerWaveBanner > 0 && !IsGameOver {
+			engine.DrawText(renderer, "Wave "+strconv.Itoa(wave), engine.Center, width/2, height/2, 50, engine.DarkGray)
+		}
+	}
+}
+
+// KillPlayer costs a life and puts the player back at the start, clearing any shots headed their way.
+func KillPlayer() {
+	player1.Teleport(InitialPlayerPosition)
+	ClearEnemyBullets()
+	numLives--
+	IsGameOver = numLives <= 0
+}
@@ -0,0 +1,83 @@
+package spaceinvaders
+
+import (
+	"hackweek/engine"
+	"testing"
+)
+
+const testTickDuration = 1.0 / engine.DefaultTickRate
+
+// startTestGame sets the game up on the bundled waves with the player driven from code.
+func startTestGame(t *testing.T, seed int64, classic bool) *engine.ProgrammaticInput {
+	var err error
+	waveScripts, err = LoadBundledWaves()
+	if err != nil {
+		t.Fatal(err)
+	}
+	isClassicMode = classic
+	rng = engine.NewRand(seed)
+	input := &engine.ProgrammaticInput{}
+	player1.Input = input
+	SetupGame()
+	return input
+}
+
+func countRectangles(frame *engine.RecordingRenderer, color engine.Color) int {
+	count := 0
+	for _, call := range frame.CallsOfKind(engine.RectangleCall) {
+		if call.Color == color {
+			count++
+		}
+	}
+	return count
+}
+
+func TestSpaceInvadersShootsTheFormation(t *testing.T) {
+	defer func() { isClassicMode = false }()
+	input := startTestGame(t, 1, true)
+	if frame := scenes.DrawFrame(1); !frame.HasText("GO Space Invaders") {
+		t.Fatal("title screen isn't showing")
+	}
+	scenes.Press(input, engine.Confirm, testTickDuration)
+	if frame := scenes.DrawFrame(1); !frame.HasText("Wave 1") || !frame.HasText("Lives 3") || !frame.HasText("Score 0") {
+		t.Fatal("wave banner and HUD aren't drawn once playing")
+	}
+
+	input.Set(engine.Shoot, true)
+	scenes.Update(testTickDuration)
+	if bullets := countRectangles(scenes.DrawFrame(1), engine.Orange); bullets != 1 {
+		t.Fatalf("drew %d bullets after firing once", bullets)
+	}
+	for tick := 0; tick < 10*engine.DefaultTickRate && player1.Score == 0; tick++ {
+		scenes.Update(testTickDuration)
+	}
+	if player1.Score == 0 || numEnemiesKilled == 0 {
+		t.Fatal("shooting straight up never hit the formation")
+	}
+	if frame := scenes.DrawFrame(1); frame.HasText("Score 0") {
+		t.Error("score drawn hasn't changed")
+	}
+}
+
+func TestSpaceInvadersGameOver(t *testing.T) {
+	input := startTestGame(t, 3, false)
+	scenes.Press(input, engine.Confirm, testTickDuration)
+
+	// Stand still without shooting until the invaders win
+	for tick := 0; tick < 600*engine.DefaultTickRate && scenes.Top() == playingScene; tick++ {
+		scenes.Update(testTickDuration)
+	}
+	frame := scenes.DrawFrame(1)
+	if scenes.Top() != gameOverScene || !frame.HasText("Game Over") || !frame.HasText("Lives 0") {
+		t.Fatalf("want game over drawn over the game, scene is %q", scenes.Top().Name)
+	}
+
+	scenes.Press(input, engine.Pause, testTickDuration)
+	if scenes.Top() != gameOverScene {
+		t.Error("pause left the game over screen")
+	}
+	scenes.Press(input, engine.Confirm, testTickDuration)
+	if frame := scenes.DrawFrame(1); scenes.Top() != playingScene || !frame.HasText("Lives 3") || !frame.HasText("Wave 1") {
+		t.Error("Enter didn't start a new game")
+	}
+}
@@ -0,0 +1,107 @@
+package spaceinvaders
+
+import (
+	"hackweek/engine"
+	"math"
+)
+
+const (
+	FormationSpeedUpPerWave = 0.15 // Fraction added to the classic formation's speed every wave
+	WaveBannerSeconds       = 2
+)
+
+var waveScripts []*WaveScript
+var wave int
+var waveScript *WaveScript // What the current wave spawns, nil in classic mode
+var waveTime float32       // Seconds since the current wave started
+var nextSpawnEvent int
+var waveSpeedScale float32 = 1 // Goes up every time the script starts over
+var waveLoops int              // Times the script has started over
+var waveTimeScale float32 = 1  // Goes down every time the script starts over, to MinScriptTimeScale
+var m_TimerWaveBanner float32
+
+// StartWave clears the board and sets up wave number, rebuilding the bunkers.
+func StartWave(number int) {
+	wave = number
+	numEnemiesKilled = 0
+	m_TimerWaveBanner = WaveBannerSeconds
+
+	for _, enemy := range enemies {
+		enemy.isActive = false
+	}
+	for i := 0; i < MaxNumBullets; i++ {
+		bullets[i].isActive = false
+	}
+	ClearEnemyBullets()
+	SetupBunkers()
+	if isClassicMode {
+		waveScript = nil
+		SetupFormation()
+		return
+	}
+
+	waveLoops = (wave - 1) / len(waveScripts)
+	waveScript = waveScripts[(wave-1)%len(waveScripts)]
+	waveSpeedScale = 1 + ScriptRepeatSpeedUp*float32(waveLoops)
+	waveTimeScale = float32(math.Max(math.Pow(ScriptRepeatTimeScale, float64(waveLoops)), MinScriptTimeScale))
+	waveTime = 0
+	nextSpawnEvent = 0
+	numEnemiesThisLevel = waveScript.NumEnemies(waveLoops)
+	numEnemiesToSpawn = numEnemiesThisLevel
+}
+
+// UpdateWaveScript spawns everything in the wave's timeline that is due, with
+// the timeline squeezed and the spawns grown for how often the script has looped.
+func UpdateWaveScript(deltaTime float32) {
+	waveTime += deltaTime
+	for nextSpawnEvent < len(waveScript.Events) && waveScript.Events[nextSpawnEvent].Time*waveTimeScale <= waveTime {
+		event := waveScript.Events[nextSpawnEvent]
+		nextSpawnEvent++
+		velocity := engine.Vector2Scale(event.Velocity, waveSpeedScale)
+		count := event.LoopedCount(waveLoops)
+		for n := 0; n < count; n++ {
+			position := engine.Vector2{event.X, SpawnTop}
+			switch event.Pattern {
+			case AtX:
+				position.Y -= float32(n * SpawnStackSpacing)
+			case RandomX:
+				position.X = float32(rng.Intn(engine.ScreenWidth))
+			case SpreadX:
+				position.X = float32(engine.ScreenWidth) * (float32(n) + 0.5) / float32(count)
+			}
+			SpawnEnemy(event.Kind, position, velocity)
+		}
+	}
+}
+
+// SpawnEnemy activates a free enemy from the pool, growing it if they're all in use.
+func SpawnEnemy(kind EnemyKind, position engine.Vector2, velocity engine.Vector2) *Enemy {
+	var enemy *Enemy
+	for _, candidate := range enemies {
+		if !candidate.isActive {
+			enemy = candidate
+			break
+		}
+	}
+	if enemy == nil {
+		EnsureEnemyPool(len(enemies) + 1)
+		enemy = enemies[len(enemies)-1]
+	}
+	enemy.Spawn(kind, position, velocity)
+	numEnemiesToSpawn--
+	return enemy
+}
+
+// EnsureEnemyPool grows the enemy pool to hold at least size enemies.
+func EnsureEnemyPool(size int) {
+	for len(enemies) < size {
+		enemy := &Enemy{}
+		enemy.Size = engine.Vector2{20, 20}
+		enemies = append(enemies, enemy)
+	}
+}
+
+// IsWaveCleared is true once every invader in the wave has been destroyed.
+func IsWaveCleared() bool {
+	return numEnemiesKilled >= numEnemiesThisLevel
+}
@@ -0,0 +1,74 @@
+package spaceinvaders
+
+import (
+	"hackweek/engine"
+	"strings"
+	"testing"
+)
+
+func TestWaveScriptGrowsWhenItLoops(t *testing.T) {
+	var err error
+	waveScripts, err = LoadBundledWaves()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rng = engine.NewRand(1)
+	player1.Input = &engine.ProgrammaticInput{}
+	SetupGame()
+
+	// Run each wave for as long as its script takes the first time round and see what has spawned
+	lastEventTime := func() float32 {
+		return waveScript.Events[len(waveScript.Events)-1].Time
+	}
+	StartWave(1)
+	firstEnemies, firstDuration := numEnemiesThisLevel, lastEventTime()
+	for loops := 1; loops <= 3; loops++ {
+		StartWave(1 + loops*len(waveScripts))
+		if numEnemiesThisLevel <= firstEnemies {
+			t.Errorf("loop %d spawns %d enemies, no more than the first time's %d", loops, numEnemiesThisLevel, firstEnemies)
+		}
+		UpdateWaveScript(firstDuration * waveTimeScale)
+		if numEnemiesToSpawn != 0 || nextSpawnEvent != len(waveScript.Events) {
+			t.Errorf("loop %d still has %d enemies to spawn once the squeezed timeline is over", loops, numEnemiesToSpawn)
+		}
+		if waveTimeScale >= 1 || waveSpeedScale <= 1 {
+			t.Errorf("loop %d runs at time scale %v and speed scale %v", loops, waveTimeScale, waveSpeedScale)
+		}
+		firstEnemies = numEnemiesThisLevel
+	}
+}
+
+func TestWaveLargerThanThePoolFires(t *testing.T) {
+	var err error
+	waveScripts, err = ParseWaves("big.txt", strings.NewReader("wave: Big\nspawn: time=0 kind=crab x=spread count=10 vy=60\nspawn: time=100 kind=crab x=spread count=50\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rng = engine.NewRand(1)
+	player1.Input = &engine.ProgrammaticInput{}
+	SetupGame()
+	StartWave(1)
+	if numEnemiesThisLevel <= InitialNumEnemies {
+		t.Fatalf("wave has %d enemies, want more than the pool's %d", numEnemiesThisLevel, InitialNumEnemies)
+	}
+
+	// Shoot with the first few on screen, before the rest of the wave has taken its place in the pool
+	for tick := 0; tick < 5*engine.DefaultTickRate; tick++ {
+		UpdateWaveScript(1.0 / engine.DefaultTickRate)
+		for _, enemy := range enemies {
+			if enemy.isActive {
+				MoveEnemy(enemy, 1.0/engine.DefaultTickRate)
+			}
+		}
+		UpdateEnemyBullets(1.0 / engine.DefaultTickRate)
+	}
+	fired := 0
+	for _, bullet := range enemyBullets {
+		if bullet.isActive {
+			fired++
+		}
+	}
+	if fired == 0 {
+		t.Error("no enemy fired")
+	}
+}